@@ -5,6 +5,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+
+	"github.com/block52/go-pvm/internal/rpc"
 )
 
 const (
@@ -19,21 +21,15 @@ func main() {
 		fmt.Fprintf(w, `{"status":"healthy","version":"%s","service":"go-pvm-rpc-server"}`, version)
 	})
 
-	// Root endpoint
+	// Root endpoint, JSON-RPC over POST
+	handler := rpc.NewHandler()
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "GET" {
 			fmt.Fprintf(w, "Go-PVM RPC Server v%s", version)
 			return
 		}
 
-		if r.Method == "POST" {
-			// TODO: Implement RPC handler
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintf(w, `{"error":"RPC handler not yet implemented"}`)
-			return
-		}
-
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		handler.ServeHTTP(w, r)
 	})
 
 	serverPort := os.Getenv("PORT")
@@ -0,0 +1,240 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned when verifying or executing an action
+var (
+	ErrNotYourTurn   = errors.New("not player's turn")
+	ErrInvalidIndex  = errors.New("invalid action index")
+	ErrInvalidRound  = errors.New("action not allowed in this round")
+	ErrIllegalAction = errors.New("illegal action")
+	ErrInvalidAmount = errors.New("invalid amount")
+)
+
+// Game is the part of a poker engine that actions read and update.
+// Engines record turns through AddTurn and move the hand on themselves.
+// GetNextPost returns the blind or ante due next, or nil once all are in.
+// GetMaxRuns returns the most times the board may be run while all-in
+// players choose, or 0 when they are not choosing. Under fixed-limit betting
+// GetFixedBet is the size of a bet this round and GetRaiseCap the most bets
+// and raises allowed, 0 for no cap.
+type Game interface {
+	GetCurrentRound() types.TexasHoldemRound
+	GetNextPlayerToAct() (types.IPlayer, error)
+	GetActionIndex() int
+	GetSmallBlind() *big.Int
+	GetBigBlind() *big.Int
+	GetBettingStructure() types.BettingStructure
+	GetFixedBet() *big.Int
+	GetRaiseCap() int
+	GetPot() *big.Int
+	GetNextPost() *managers.Post
+	GetMaxRuns() int
+	GetTurns(round types.TexasHoldemRound) []types.Turn
+	AddTurn(player types.IPlayer, action types.PlayerActionType, amount *big.Int)
+}
+
+// PlayerActions lists every player action in the order legal actions are offered
+var PlayerActions = []types.PlayerActionType{
+	types.ActionSmallBlind,
+	types.ActionBigBlind,
+	types.ActionAnte,
+	types.ActionStraddle,
+	types.ActionBringIn,
+	types.ActionFold,
+	types.ActionCheck,
+	types.ActionCall,
+	types.ActionBet,
+	types.ActionRaise,
+	types.ActionAllIn,
+	types.ActionShow,
+	types.ActionMuck,
+	types.ActionRunIt,
+	types.ActionDraw,
+}
+
+// New returns the action implementing a player action type
+func New(game Game, action types.PlayerActionType) (types.IAction, error) {
+	switch action {
+	case types.ActionSmallBlind:
+		return NewSmallBlind(game), nil
+	case types.ActionBigBlind:
+		return NewBigBlind(game), nil
+	case types.ActionAnte:
+		return NewAnte(game), nil
+	case types.ActionStraddle:
+		return NewStraddle(game), nil
+	case types.ActionBringIn:
+		return NewBringIn(game), nil
+	case types.ActionFold:
+		return NewFold(game), nil
+	case types.ActionCheck:
+		return NewCheck(game), nil
+	case types.ActionCall:
+		return NewCall(game), nil
+	case types.ActionBet:
+		return NewBet(game), nil
+	case types.ActionRaise:
+		return NewRaise(game), nil
+	case types.ActionAllIn:
+		return NewAllIn(game), nil
+	case types.ActionShow:
+		return NewShow(game), nil
+	case types.ActionMuck:
+		return NewMuck(game), nil
+	case types.ActionRunIt:
+		return NewRunIt(game), nil
+	case types.ActionDraw:
+		return NewDraw(game), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", ErrIllegalAction, action)
+	}
+}
+
+// base holds what every action shares
+type base struct {
+	game Game
+}
+
+// checkTurn verifies that it is the player's turn
+func (b base) checkTurn(player types.IPlayer) error {
+	next, err := b.game.GetNextPlayerToAct()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotYourTurn, err)
+	}
+	if next.GetAddress() != player.GetAddress() {
+		return fmt.Errorf("%w: %s acted but %s is next", ErrNotYourTurn, player.GetAddress(), next.GetAddress())
+	}
+	return nil
+}
+
+// checkBetting verifies that a betting round is under way and it is the
+// player's turn
+func (b base) checkBetting(player types.IPlayer, action types.PlayerActionType) error {
+	round := b.game.GetCurrentRound()
+	switch round {
+	case types.RoundFlop, types.RoundTurn, types.RoundRiver,
+		types.RoundFourthStreet, types.RoundFifthStreet, types.RoundSixthStreet, types.RoundSeventhStreet,
+		types.RoundPostFirstDraw, types.RoundPostSecondDraw, types.RoundPostThirdDraw:
+	case types.RoundPreFlop, types.RoundPreDraw:
+		if !b.posted(round, types.ActionBigBlind) || b.game.GetNextPost() != nil {
+			return fmt.Errorf("%w: cannot %s before the blinds are posted", ErrInvalidRound, action)
+		}
+	case types.RoundThirdStreet:
+		if b.game.GetNextPost() != nil {
+			return fmt.Errorf("%w: cannot %s before the antes and bring-in are posted", ErrInvalidRound, action)
+		}
+	default:
+		return fmt.Errorf("%w: cannot %s in %s", ErrInvalidRound, action, round)
+	}
+	if b.game.GetMaxRuns() > 0 {
+		return fmt.Errorf("%w: betting is over, choose how many times to run the board", ErrIllegalAction)
+	}
+	return b.checkTurn(player)
+}
+
+// posted reports whether a blind has been posted in a round of the current hand
+func (b base) posted(round types.TexasHoldemRound, blind types.PlayerActionType) bool {
+	for _, turn := range b.game.GetTurns(round) {
+		if turn.Action == blind {
+			return true
+		}
+	}
+	return false
+}
+
+// bets replays the betting in the current round
+func (b base) bets() *managers.BetManager {
+	return managers.NewBetManager(b.game.GetBigBlind(), b.game.GetTurns(b.game.GetCurrentRound()))
+}
+
+// toCall returns what the player must add to match the largest bet
+func (b base) toCall(bets *managers.BetManager, player types.IPlayer) *big.Int {
+	return new(big.Int).Sub(bets.GetLargestBet(), bets.GetBet(player.GetAddress()))
+}
+
+// raiseRange returns the fewest and most chips a bet or raise may put in
+// under the table's betting structure, before the player's stack is taken
+// into account. A bet is a raise with nothing to call.
+func (b base) raiseRange(bets *managers.BetManager, player types.IPlayer) (*big.Int, *big.Int, error) {
+	toCall := b.toCall(bets, player)
+	minimum := new(big.Int).Add(toCall, bets.GetMinRaise())
+
+	switch structure := b.game.GetBettingStructure(); structure {
+	case types.BettingFixedLimit:
+		if limit := b.game.GetRaiseCap(); limit > 0 && bets.GetRaises() >= limit {
+			return nil, nil, fmt.Errorf("%w: betting is capped at %d bets this round", ErrIllegalAction, limit)
+		}
+		// Raises go up by the fixed bet, and a bet short of it, such as
+		// a bring-in, is first completed to the fixed bet
+		size := b.game.GetFixedBet()
+		target := new(big.Int).Add(bets.GetLargestBet(), size)
+		if bets.GetLargestBet().Cmp(size) < 0 {
+			target = size
+		}
+		amount := target.Sub(target, bets.GetBet(player.GetAddress()))
+		return amount, amount, nil
+	case types.BettingPotLimit:
+		// The largest raise is the size of the pot once the player has called
+		maximum := new(big.Int).Add(b.game.GetPot(), toCall)
+		maximum.Add(maximum, toCall)
+		if maximum.Cmp(minimum) < 0 {
+			maximum = minimum
+		}
+		return minimum, maximum, nil
+	default:
+		return minimum, new(big.Int).Set(player.GetChips()), nil
+	}
+}
+
+// execute checks the index and amount of an action against its legal range
+// and returns the amount to apply
+func (b base) execute(action types.IAction, player types.IPlayer, index int, amount *big.Int) (*big.Int, error) {
+	if expected := b.game.GetActionIndex(); index != expected {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrInvalidIndex, expected, index)
+	}
+
+	legal, err := action.Verify(player)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+	if amount.Cmp(legal.MinAmount) < 0 || amount.Cmp(legal.MaxAmount) > 0 {
+		if legal.MinAmount.Cmp(legal.MaxAmount) == 0 {
+			return nil, fmt.Errorf("%w: %s must be %s, got %s", ErrInvalidAmount, action.Type(), legal.MinAmount, amount)
+		}
+		return nil, fmt.Errorf("%w: %s must be between %s and %s, got %s",
+			ErrInvalidAmount, action.Type(), legal.MinAmount, legal.MaxAmount, amount)
+	}
+	return amount, nil
+}
+
+// commitChips moves chips from the player's stack into the pot and records
+// the turn. A player left with no chips is all-in.
+func (b base) commitChips(player types.IPlayer, action types.PlayerActionType, amount *big.Int) {
+	player.SetChips(new(big.Int).Sub(player.GetChips(), amount))
+	if player.GetChips().Sign() == 0 {
+		player.SetStatus(types.StatusAllIn)
+	}
+	b.game.AddTurn(player, action, amount)
+}
+
+// fixed returns a range allowing exactly amount
+func fixed(amount *big.Int) *types.Range {
+	return &types.Range{MinAmount: new(big.Int).Set(amount), MaxAmount: new(big.Int).Set(amount)}
+}
+
+// between returns a range from min to max inclusive
+func between(min, max *big.Int) *types.Range {
+	return &types.Range{MinAmount: new(big.Int).Set(min), MaxAmount: new(big.Int).Set(max)}
+}
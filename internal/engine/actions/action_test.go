@@ -0,0 +1,463 @@
+package actions
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// stubGame is a minimal Game with a fixed player to act
+type stubGame struct {
+	round   types.TexasHoldemRound
+	next    string
+	players map[string]*models.Player
+	turns   map[types.TexasHoldemRound][]types.Turn
+	index   int
+	posts   []managers.Post // Forced bets due, in order
+	maxRuns int
+	betting types.BettingStructure // No-limit if unset
+	raises  int                    // Fixed-limit raise cap, 0 for none
+}
+
+// newStubGame seats players with 100 chips each, blinds 1/2 already posted
+// by the first two, in the pre-flop round
+func newStubGame(addresses ...string) *stubGame {
+	g := &stubGame{
+		round:   types.RoundPreFlop,
+		players: make(map[string]*models.Player),
+		turns:   make(map[types.TexasHoldemRound][]types.Turn),
+	}
+	for i, address := range addresses {
+		g.players[address] = models.NewPlayer(address, big.NewInt(100), i+1)
+	}
+	g.AddTurn(g.players[addresses[0]], types.ActionSmallBlind, big.NewInt(1))
+	g.players[addresses[0]].Chips = big.NewInt(99)
+	g.AddTurn(g.players[addresses[1]], types.ActionBigBlind, big.NewInt(2))
+	g.players[addresses[1]].Chips = big.NewInt(98)
+	return g
+}
+
+func (g *stubGame) GetCurrentRound() types.TexasHoldemRound     { return g.round }
+func (g *stubGame) GetActionIndex() int                         { return g.index + 1 }
+func (g *stubGame) GetSmallBlind() *big.Int                     { return big.NewInt(1) }
+func (g *stubGame) GetBigBlind() *big.Int                       { return big.NewInt(2) }
+func (g *stubGame) GetMaxRuns() int                             { return g.maxRuns }
+func (g *stubGame) GetBettingStructure() types.BettingStructure { return g.betting }
+func (g *stubGame) GetRaiseCap() int                            { return g.raises }
+
+// GetFixedBet returns a small bet of 2 pre-flop and on the flop and a big bet of 4 after
+func (g *stubGame) GetFixedBet() *big.Int {
+	if g.round == types.RoundPreFlop || g.round == types.RoundFlop {
+		return big.NewInt(2)
+	}
+	return big.NewInt(4)
+}
+
+// GetPot returns every chip put in this hand
+func (g *stubGame) GetPot() *big.Int {
+	pot := big.NewInt(0)
+	for _, turns := range g.turns {
+		for _, turn := range turns {
+			pot.Add(pot, turn.Amount)
+		}
+	}
+	return pot
+}
+
+func (g *stubGame) GetNextPlayerToAct() (types.IPlayer, error) {
+	if p, ok := g.players[g.next]; ok {
+		return p, nil
+	}
+	return nil, errors.New("nobody to act")
+}
+
+// GetNextPost returns the first of g.posts not yet posted
+func (g *stubGame) GetNextPost() *managers.Post {
+	for i, post := range g.posts {
+		posted := false
+		for _, turn := range g.turns[types.RoundPreFlop] {
+			if turn.PlayerID == post.Address && turn.Action == post.Action {
+				posted = true
+			}
+		}
+		if !posted {
+			return &g.posts[i]
+		}
+	}
+	return nil
+}
+
+func (g *stubGame) GetTurns(round types.TexasHoldemRound) []types.Turn {
+	return g.turns[round]
+}
+
+func (g *stubGame) AddTurn(player types.IPlayer, action types.PlayerActionType, amount *big.Int) {
+	g.index++
+	g.turns[g.round] = append(g.turns[g.round], types.Turn{
+		PlayerID: player.GetAddress(),
+		Action:   action,
+		Amount:   new(big.Int).Set(amount),
+		Index:    g.index,
+	})
+}
+
+// play executes an action for a player, making them the player to act
+func (g *stubGame) play(t *testing.T, address string, action types.PlayerActionType, amount int64) {
+	t.Helper()
+
+	g.next = address
+	a, err := New(g, action)
+	if err != nil {
+		t.Fatalf("New(%s) failed: %v", action, err)
+	}
+	if err := a.Execute(g.players[address], g.GetActionIndex(), big.NewInt(amount)); err != nil {
+		t.Fatalf("%s %s %d failed: %v", address, action, amount, err)
+	}
+}
+
+// verify returns the legal range of an action for the player to act
+func (g *stubGame) verify(address string, action types.PlayerActionType) (*types.Range, error) {
+	g.next = address
+	a, err := New(g, action)
+	if err != nil {
+		return nil, err
+	}
+	return a.Verify(g.players[address])
+}
+
+// expectRange fails unless the action is legal with the given range
+func expectRange(t *testing.T, g *stubGame, address string, action types.PlayerActionType, min, max int64) {
+	t.Helper()
+
+	r, err := g.verify(address, action)
+	if err != nil {
+		t.Fatalf("Expected %s to be legal for %s, got %v", action, address, err)
+	}
+	if r.MinAmount.Int64() != min || r.MaxAmount.Int64() != max {
+		t.Errorf("Expected %s range [%d, %d], got [%s, %s]", action, min, max, r.MinAmount, r.MaxAmount)
+	}
+}
+
+// expectIllegal fails unless verifying the action returns err
+func expectIllegal(t *testing.T, g *stubGame, address string, action types.PlayerActionType, err error) {
+	t.Helper()
+
+	if _, got := g.verify(address, action); !errors.Is(got, err) {
+		t.Errorf("Expected %s for %s to fail with %v, got %v", action, address, err, got)
+	}
+}
+
+// TestNoLimitBetting tests ranges for each betting action
+func TestNoLimitBetting(t *testing.T) {
+	t.Run("should size pre-flop actions from the big blind", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+
+		expectRange(t, g, "carol", types.ActionFold, 0, 0)
+		expectRange(t, g, "carol", types.ActionCall, 2, 2)
+		expectRange(t, g, "carol", types.ActionRaise, 4, 100)
+		expectRange(t, g, "carol", types.ActionAllIn, 100, 100)
+		expectIllegal(t, g, "carol", types.ActionCheck, ErrIllegalAction)
+		expectIllegal(t, g, "carol", types.ActionBet, ErrIllegalAction)
+	})
+
+	t.Run("should give the big blind a check option", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.play(t, "carol", types.ActionCall, 2)
+		g.play(t, "alice", types.ActionCall, 1)
+
+		expectRange(t, g, "bob", types.ActionCheck, 0, 0)
+		expectRange(t, g, "bob", types.ActionRaise, 2, 98)
+	})
+
+	t.Run("should open post-flop betting at the big blind", func(t *testing.T) {
+		g := newStubGame("alice", "bob")
+		g.round = types.RoundFlop
+
+		expectRange(t, g, "bob", types.ActionCheck, 0, 0)
+		expectRange(t, g, "bob", types.ActionBet, 2, 98)
+		expectIllegal(t, g, "bob", types.ActionCall, ErrIllegalAction)
+		expectIllegal(t, g, "bob", types.ActionRaise, ErrIllegalAction)
+	})
+
+	t.Run("should set the minimum raise to the last raise size", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol", "dave")
+		g.play(t, "carol", types.ActionRaise, 10) // raises by 8 to 10
+		g.play(t, "dave", types.ActionRaise, 30)  // raises by 20 to 30
+
+		// Alice has 1 in and must raise to at least 50
+		expectRange(t, g, "alice", types.ActionRaise, 49, 99)
+	})
+
+	t.Run("should not reopen the action after an incomplete all-in raise", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol", "dave")
+		g.players["dave"].Chips = big.NewInt(15)
+		g.play(t, "carol", types.ActionRaise, 10)
+		g.play(t, "dave", types.ActionAllIn, 15) // raises by 5, less than 8
+
+		// Carol already acted and may only call or fold
+		expectRange(t, g, "carol", types.ActionCall, 5, 5)
+		expectIllegal(t, g, "carol", types.ActionRaise, ErrIllegalAction)
+		expectIllegal(t, g, "carol", types.ActionAllIn, ErrIllegalAction)
+
+		// Alice has not acted and may still raise by the last full raise of 8
+		expectRange(t, g, "alice", types.ActionRaise, 22, 99)
+	})
+
+	t.Run("should require all-in when a call takes the whole stack", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.players["carol"].Chips = big.NewInt(2)
+
+		expectIllegal(t, g, "carol", types.ActionCall, ErrIllegalAction)
+		expectRange(t, g, "carol", types.ActionAllIn, 2, 2)
+	})
+
+	t.Run("should reject actions out of turn", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.next = "carol"
+
+		_, err := NewCall(g).Verify(g.players["alice"])
+		if !errors.Is(err, ErrNotYourTurn) || !strings.Contains(err.Error(), "carol is next") {
+			t.Errorf("Expected ErrNotYourTurn naming carol, got %v", err)
+		}
+	})
+}
+
+// TestPotLimitBetting tests bets and raises capped at the pot
+func TestPotLimitBetting(t *testing.T) {
+	t.Run("should cap a raise at the pot after calling", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.betting = types.BettingPotLimit
+
+		// 3 in the pot and 2 to call makes a pot of 5 to raise by
+		expectRange(t, g, "carol", types.ActionRaise, 4, 7)
+		expectIllegal(t, g, "carol", types.ActionAllIn, ErrIllegalAction)
+		g.play(t, "carol", types.ActionRaise, 7)
+
+		// 10 in the pot and 6 to call
+		expectRange(t, g, "alice", types.ActionRaise, 11, 22)
+	})
+
+	t.Run("should cap a bet at the pot", func(t *testing.T) {
+		g := newStubGame("alice", "bob")
+		g.betting = types.BettingPotLimit
+		g.round = types.RoundFlop
+
+		expectRange(t, g, "bob", types.ActionBet, 2, 3)
+	})
+
+	t.Run("should allow an all-in within the limit", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.betting = types.BettingPotLimit
+		g.players["carol"].Chips = big.NewInt(5)
+
+		expectRange(t, g, "carol", types.ActionRaise, 4, 5)
+		expectRange(t, g, "carol", types.ActionAllIn, 5, 5)
+	})
+}
+
+// TestFixedLimitBetting tests fixed bet sizes and the raise cap
+func TestFixedLimitBetting(t *testing.T) {
+	t.Run("should raise by the small bet until capped", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol", "dave")
+		g.betting = types.BettingFixedLimit
+		g.raises = 4
+
+		expectRange(t, g, "carol", types.ActionRaise, 4, 4)
+		expectIllegal(t, g, "carol", types.ActionAllIn, ErrIllegalAction)
+		g.play(t, "carol", types.ActionRaise, 4)
+		g.play(t, "dave", types.ActionRaise, 6)
+		g.play(t, "alice", types.ActionRaise, 7)
+
+		// The big blind and three raises reach the cap
+		expectIllegal(t, g, "bob", types.ActionRaise, ErrIllegalAction)
+		expectIllegal(t, g, "bob", types.ActionAllIn, ErrIllegalAction)
+		expectRange(t, g, "bob", types.ActionCall, 6, 6)
+	})
+
+	t.Run("should bet the big bet on the turn", func(t *testing.T) {
+		g := newStubGame("alice", "bob")
+		g.betting = types.BettingFixedLimit
+		g.round = types.RoundTurn
+
+		expectRange(t, g, "bob", types.ActionBet, 4, 4)
+		g.play(t, "bob", types.ActionBet, 4)
+		expectRange(t, g, "alice", types.ActionRaise, 8, 8)
+	})
+
+	t.Run("should raise without a cap when there is none", func(t *testing.T) {
+		g := newStubGame("alice", "bob")
+		g.betting = types.BettingFixedLimit
+		g.play(t, "alice", types.ActionRaise, 3)
+		for i := 0; i < 3; i++ {
+			g.play(t, "bob", types.ActionRaise, 4)
+			g.play(t, "alice", types.ActionRaise, 4)
+		}
+
+		expectRange(t, g, "bob", types.ActionRaise, 4, 4)
+	})
+}
+
+// TestExecute tests index and amount checks and state changes
+func TestExecute(t *testing.T) {
+	t.Run("should reject a stale index", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.next = "carol"
+
+		err := NewCall(g).Execute(g.players["carol"], 2, big.NewInt(2))
+		if !errors.Is(err, ErrInvalidIndex) {
+			t.Errorf("Expected ErrInvalidIndex, got %v", err)
+		}
+	})
+
+	t.Run("should reject an amount outside the legal range", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.next = "carol"
+
+		err := NewRaise(g).Execute(g.players["carol"], g.GetActionIndex(), big.NewInt(3))
+		if !errors.Is(err, ErrInvalidAmount) || !strings.Contains(err.Error(), "between 4 and 100") {
+			t.Errorf("Expected ErrInvalidAmount with the range, got %v", err)
+		}
+	})
+
+	t.Run("should move chips and record the turn", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.play(t, "carol", types.ActionRaise, 6)
+
+		if g.players["carol"].Chips.Int64() != 94 {
+			t.Errorf("Expected 94 chips, got %s", g.players["carol"].Chips)
+		}
+		turns := g.GetTurns(types.RoundPreFlop)
+		last := turns[len(turns)-1]
+		if last.PlayerID != "carol" || last.Action != types.ActionRaise || last.Amount.Int64() != 6 || last.Index != 3 {
+			t.Errorf("Unexpected turn %+v", last)
+		}
+	})
+
+	t.Run("should set statuses for fold and all-in", func(t *testing.T) {
+		g := newStubGame("alice", "bob", "carol")
+		g.play(t, "carol", types.ActionAllIn, 100)
+		g.play(t, "alice", types.ActionFold, 0)
+
+		if g.players["carol"].Status != types.StatusAllIn || g.players["alice"].Status != types.StatusFolded {
+			t.Errorf("Unexpected statuses %s and %s", g.players["carol"].Status, g.players["alice"].Status)
+		}
+	})
+}
+
+// TestBlinds tests blind and ante posting
+func TestBlinds(t *testing.T) {
+	g := &stubGame{
+		round:   types.RoundPreFlop,
+		players: map[string]*models.Player{"alice": models.NewPlayer("alice", big.NewInt(1), 1), "bob": models.NewPlayer("bob", big.NewInt(100), 2)},
+		turns:   make(map[types.TexasHoldemRound][]types.Turn),
+		posts: []managers.Post{
+			{Address: "alice", Action: types.ActionSmallBlind, Amount: big.NewInt(1)},
+			{Address: "bob", Action: types.ActionBigBlind, Amount: big.NewInt(2)},
+		},
+	}
+
+	expectIllegal(t, g, "bob", types.ActionBigBlind, ErrIllegalAction)
+	expectIllegal(t, g, "alice", types.ActionFold, ErrInvalidRound)
+
+	g.play(t, "alice", types.ActionSmallBlind, 1)
+	if g.players["alice"].Status != types.StatusAllIn {
+		t.Errorf("Expected a one-chip small blind to be all-in")
+	}
+	expectIllegal(t, g, "alice", types.ActionSmallBlind, ErrIllegalAction)
+	expectRange(t, g, "bob", types.ActionBigBlind, 2, 2)
+
+	t.Run("should post the big blind alone over a dead small blind", func(t *testing.T) {
+		g := &stubGame{
+			round:   types.RoundPreFlop,
+			players: map[string]*models.Player{"bob": models.NewPlayer("bob", big.NewInt(100), 2)},
+			turns:   make(map[types.TexasHoldemRound][]types.Turn),
+			posts:   []managers.Post{{Address: "bob", Action: types.ActionBigBlind, Amount: big.NewInt(2)}},
+		}
+
+		expectIllegal(t, g, "bob", types.ActionSmallBlind, ErrIllegalAction)
+		expectRange(t, g, "bob", types.ActionBigBlind, 2, 2)
+	})
+
+	t.Run("should post antes after the blinds and before betting", func(t *testing.T) {
+		g := newStubGame("alice", "bob")
+		g.players["alice"].Chips = big.NewInt(3)
+		g.posts = []managers.Post{
+			{Address: "alice", Action: types.ActionAnte, Amount: big.NewInt(5)},
+			{Address: "bob", Action: types.ActionAnte, Amount: big.NewInt(5)},
+		}
+
+		expectIllegal(t, g, "bob", types.ActionAnte, ErrIllegalAction)
+		expectRange(t, g, "alice", types.ActionAnte, 3, 3)
+		g.play(t, "alice", types.ActionAnte, 3)
+		if g.players["alice"].Status != types.StatusAllIn {
+			t.Errorf("Expected a short ante to be all-in")
+		}
+
+		expectIllegal(t, g, "bob", types.ActionCall, ErrInvalidRound)
+		g.play(t, "bob", types.ActionAnte, 5)
+		expectRange(t, g, "bob", types.ActionCheck, 0, 0)
+	})
+}
+
+// TestShowdown tests show and muck
+func TestShowdown(t *testing.T) {
+	g := newStubGame("alice", "bob")
+	g.round = types.RoundShowdown
+
+	expectIllegal(t, g, "alice", types.ActionMuck, ErrIllegalAction)
+	expectIllegal(t, g, "alice", types.ActionCheck, ErrInvalidRound)
+	g.play(t, "alice", types.ActionShow, 0)
+
+	g.play(t, "bob", types.ActionMuck, 0)
+	if g.players["bob"].Status != types.StatusFolded {
+		t.Errorf("Expected mucked hand to be folded, got %s", g.players["bob"].Status)
+	}
+}
+
+// TestRunIt tests choosing how many times to run the board
+func TestRunIt(t *testing.T) {
+	g := newStubGame("alice", "bob")
+	g.play(t, "alice", types.ActionAllIn, 99)
+	g.play(t, "bob", types.ActionAllIn, 98)
+
+	expectIllegal(t, g, "alice", types.ActionRunIt, ErrIllegalAction)
+
+	g.maxRuns = 3
+	expectRange(t, g, "alice", types.ActionRunIt, 1, 3)
+	expectIllegal(t, g, "alice", types.ActionCheck, ErrIllegalAction)
+	g.play(t, "alice", types.ActionRunIt, 2)
+
+	turns := g.GetTurns(types.RoundPreFlop)
+	if last := turns[len(turns)-1]; last.Action != types.ActionRunIt || last.Amount.Int64() != 2 {
+		t.Errorf("Unexpected turn %+v", last)
+	}
+	if g.players["alice"].Chips.Sign() != 0 {
+		t.Errorf("Expected choosing runs to move no chips")
+	}
+}
+
+// TestDraw tests discarding in a draw round
+func TestDraw(t *testing.T) {
+	g := newStubGame("alice", "bob")
+	g.players["alice"].HoleCards = make([]types.Card, 5)
+
+	expectIllegal(t, g, "alice", types.ActionDraw, ErrInvalidRound)
+
+	g.round = types.RoundFirstDraw
+	expectRange(t, g, "alice", types.ActionDraw, 0, 31)
+	expectIllegal(t, g, "alice", types.ActionBet, ErrInvalidRound)
+	g.play(t, "alice", types.ActionDraw, 5)
+
+	turns := g.GetTurns(types.RoundFirstDraw)
+	if len(turns) != 1 || turns[0].Action != types.ActionDraw || turns[0].Amount.Int64() != 5 {
+		t.Errorf("Unexpected turns %+v", turns)
+	}
+	if g.players["alice"].Chips.Int64() != 99 {
+		t.Errorf("Expected drawing to move no chips")
+	}
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// AllIn puts the player's whole stack in. It may be a call for less, an
+// incomplete raise that does not reopen the action, or a full raise. Under
+// pot-limit and fixed-limit betting the stack may not be more than the
+// largest legal raise.
+type AllIn struct {
+	base
+}
+
+// NewAllIn creates an all-in action
+func NewAllIn(game Game) *AllIn {
+	return &AllIn{base{game}}
+}
+
+// Type returns types.ActionAllIn
+func (a *AllIn) Type() interface{} {
+	return types.ActionAllIn
+}
+
+// Verify returns the player's stack as the only legal amount
+func (a *AllIn) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkBetting(player, types.ActionAllIn); err != nil {
+		return nil, err
+	}
+
+	chips := player.GetChips()
+	if chips.Sign() == 0 {
+		return nil, fmt.Errorf("%w: no chips left", ErrIllegalAction)
+	}
+
+	// Going all-in for more than a call is a raise
+	bets := a.bets()
+	if chips.Cmp(a.toCall(bets, player)) <= 0 {
+		return fixed(chips), nil
+	}
+	if bets.HasActed(player.GetAddress()) {
+		return nil, fmt.Errorf("%w: action was not reopened by a full raise, call or fold", ErrIllegalAction)
+	}
+	_, maximum, err := a.raiseRange(bets, player)
+	if err != nil {
+		return nil, err
+	}
+	if chips.Cmp(maximum) > 0 {
+		return nil, fmt.Errorf("%w: stack of %s is over the most that can go in, %s", ErrIllegalAction, chips, maximum)
+	}
+	return fixed(chips), nil
+}
+
+// Execute puts the player all-in
+func (a *AllIn) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionAllIn, amount)
+	return nil
+}
@@ -0,0 +1,58 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Bet opens the betting in a round. The minimum bet is the big blind, or the
+// fixed bet under fixed-limit betting, and pot-limit bets are capped at the pot.
+type Bet struct {
+	base
+}
+
+// NewBet creates a bet action
+func NewBet(game Game) *Bet {
+	return &Bet{base{game}}
+}
+
+// Type returns types.ActionBet
+func (a *Bet) Type() interface{} {
+	return types.ActionBet
+}
+
+// Verify returns the range of chips the player may bet
+func (a *Bet) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkBetting(player, types.ActionBet); err != nil {
+		return nil, err
+	}
+
+	bets := a.bets()
+	if bets.GetLargestBet().Sign() > 0 {
+		return nil, fmt.Errorf("%w: there is already a bet of %s, raise instead", ErrIllegalAction, bets.GetLargestBet())
+	}
+	minimum, maximum, err := a.raiseRange(bets, player)
+	if err != nil {
+		return nil, err
+	}
+	if player.GetChips().Cmp(minimum) < 0 {
+		return nil, fmt.Errorf("%w: stack of %s is below the minimum bet of %s, go all-in instead",
+			ErrIllegalAction, player.GetChips(), minimum)
+	}
+	if maximum.Cmp(player.GetChips()) > 0 {
+		maximum = player.GetChips()
+	}
+	return between(minimum, maximum), nil
+}
+
+// Execute bets
+func (a *Bet) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionBet, amount)
+	return nil
+}
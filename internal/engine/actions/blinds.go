@@ -0,0 +1,195 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// SmallBlind posts the small blind, or the player's whole stack if shorter
+type SmallBlind struct {
+	base
+}
+
+// NewSmallBlind creates a small blind action
+func NewSmallBlind(game Game) *SmallBlind {
+	return &SmallBlind{base{game}}
+}
+
+// Type returns types.ActionSmallBlind
+func (a *SmallBlind) Type() interface{} {
+	return types.ActionSmallBlind
+}
+
+// Verify checks the small blind is due from the player
+func (a *SmallBlind) Verify(player types.IPlayer) (*types.Range, error) {
+	return a.verifyPost(player, types.ActionSmallBlind)
+}
+
+// Execute posts the small blind
+func (a *SmallBlind) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionSmallBlind, amount)
+	return nil
+}
+
+// BigBlind posts the big blind, or the player's whole stack if shorter
+type BigBlind struct {
+	base
+}
+
+// NewBigBlind creates a big blind action
+func NewBigBlind(game Game) *BigBlind {
+	return &BigBlind{base{game}}
+}
+
+// Type returns types.ActionBigBlind
+func (a *BigBlind) Type() interface{} {
+	return types.ActionBigBlind
+}
+
+// Verify checks the big blind is due from the player
+func (a *BigBlind) Verify(player types.IPlayer) (*types.Range, error) {
+	return a.verifyPost(player, types.ActionBigBlind)
+}
+
+// Execute posts the big blind
+func (a *BigBlind) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionBigBlind, amount)
+	return nil
+}
+
+// Ante posts an ante, or the player's whole stack if shorter. Antes go in
+// the pot without counting towards the player's bet.
+type Ante struct {
+	base
+}
+
+// NewAnte creates an ante action
+func NewAnte(game Game) *Ante {
+	return &Ante{base{game}}
+}
+
+// Type returns types.ActionAnte
+func (a *Ante) Type() interface{} {
+	return types.ActionAnte
+}
+
+// Verify checks an ante is due from the player
+func (a *Ante) Verify(player types.IPlayer) (*types.Range, error) {
+	return a.verifyPost(player, types.ActionAnte)
+}
+
+// Execute posts the ante
+func (a *Ante) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionAnte, amount)
+	return nil
+}
+
+// Straddle posts an optional straddle of two big blinds before the deal.
+// The straddler acts last pre-flop and raises must be at least its size.
+type Straddle struct {
+	base
+}
+
+// NewStraddle creates a straddle action
+func NewStraddle(game Game) *Straddle {
+	return &Straddle{base{game}}
+}
+
+// Type returns types.ActionStraddle
+func (a *Straddle) Type() interface{} {
+	return types.ActionStraddle
+}
+
+// Verify checks the player may straddle
+func (a *Straddle) Verify(player types.IPlayer) (*types.Range, error) {
+	return a.verifyPost(player, types.ActionStraddle)
+}
+
+// Execute posts the straddle
+func (a *Straddle) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionStraddle, amount)
+	return nil
+}
+
+// BringIn opens stud betting from the player showing the lowest card. They
+// may post the bring-in or complete to the small bet.
+type BringIn struct {
+	base
+}
+
+// NewBringIn creates a bring-in action
+func NewBringIn(game Game) *BringIn {
+	return &BringIn{base{game}}
+}
+
+// Type returns types.ActionBringIn
+func (a *BringIn) Type() interface{} {
+	return types.ActionBringIn
+}
+
+// Verify checks the bring-in is due from the player and returns the range
+// from the bring-in to the small bet, capped at the player's stack
+func (a *BringIn) Verify(player types.IPlayer) (*types.Range, error) {
+	r, err := a.verifyPost(player, types.ActionBringIn)
+	if err != nil {
+		return nil, err
+	}
+	complete := a.game.GetFixedBet()
+	if complete == nil || complete.Cmp(r.MinAmount) < 0 {
+		return r, nil
+	}
+	if complete.Cmp(player.GetChips()) > 0 {
+		complete = player.GetChips()
+	}
+	return between(r.MinAmount, complete), nil
+}
+
+// Execute posts the bring-in
+func (a *BringIn) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionBringIn, amount)
+	return nil
+}
+
+// verifyPost checks a blind, ante or bring-in is the next forced bet due and
+// returns its size, capped at the player's stack
+func (b base) verifyPost(player types.IPlayer, action types.PlayerActionType) (*types.Range, error) {
+	switch round := b.game.GetCurrentRound(); round {
+	case types.RoundPreFlop, types.RoundPreDraw, types.RoundThirdStreet:
+	default:
+		return nil, fmt.Errorf("%w: cannot %s in %s", ErrInvalidRound, action, round)
+	}
+	post := b.game.GetNextPost()
+	if post == nil || post.Action != action || post.Address != player.GetAddress() {
+		return nil, fmt.Errorf("%w: %s is not due from %s", ErrIllegalAction, action, player.GetAddress())
+	}
+	if err := b.checkTurn(player); err != nil {
+		return nil, err
+	}
+
+	if player.GetChips().Cmp(post.Amount) < 0 {
+		return fixed(player.GetChips()), nil
+	}
+	return fixed(post.Amount), nil
+}
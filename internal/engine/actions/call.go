@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Call matches the largest bet in the round. A player who cannot cover the
+// full amount must go all-in instead.
+type Call struct {
+	base
+}
+
+// NewCall creates a call action
+func NewCall(game Game) *Call {
+	return &Call{base{game}}
+}
+
+// Type returns types.ActionCall
+func (a *Call) Type() interface{} {
+	return types.ActionCall
+}
+
+// Verify returns the exact amount needed to call
+func (a *Call) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkBetting(player, types.ActionCall); err != nil {
+		return nil, err
+	}
+
+	toCall := a.toCall(a.bets(), player)
+	if toCall.Sign() == 0 {
+		return nil, fmt.Errorf("%w: nothing to call", ErrIllegalAction)
+	}
+	if player.GetChips().Cmp(toCall) <= 0 {
+		return nil, fmt.Errorf("%w: calling %s needs the whole stack of %s, go all-in instead",
+			ErrIllegalAction, toCall, player.GetChips())
+	}
+	return fixed(toCall), nil
+}
+
+// Execute calls
+func (a *Call) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionCall, amount)
+	return nil
+}
@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Check passes the action without betting when there is nothing to call
+type Check struct {
+	base
+}
+
+// NewCheck creates a check action
+func NewCheck(game Game) *Check {
+	return &Check{base{game}}
+}
+
+// Type returns types.ActionCheck
+func (a *Check) Type() interface{} {
+	return types.ActionCheck
+}
+
+// Verify checks the player has nothing to call
+func (a *Check) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkBetting(player, types.ActionCheck); err != nil {
+		return nil, err
+	}
+	if toCall := a.toCall(a.bets(), player); toCall.Sign() > 0 {
+		return nil, fmt.Errorf("%w: cannot check facing a bet of %s", ErrIllegalAction, toCall)
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute checks
+func (a *Check) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.game.AddTurn(player, types.ActionCheck, amount)
+	return nil
+}
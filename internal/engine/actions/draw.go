@@ -0,0 +1,52 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Draw discards hole cards in a draw game and is dealt replacements. The
+// amount is a bit mask of the cards to discard, not chips: bit 0 is the first
+// hole card, and 0 stands pat.
+type Draw struct {
+	base
+}
+
+// NewDraw creates a draw action
+func NewDraw(game Game) *Draw {
+	return &Draw{base{game}}
+}
+
+// Type returns types.ActionDraw
+func (a *Draw) Type() interface{} {
+	return types.ActionDraw
+}
+
+// Verify checks it is the player's turn in a draw round. The range covers
+// every mask of the player's hole cards.
+func (a *Draw) Verify(player types.IPlayer) (*types.Range, error) {
+	if round := a.game.GetCurrentRound(); !round.IsDraw() {
+		return nil, fmt.Errorf("%w: cannot %s in %s", ErrInvalidRound, types.ActionDraw, round)
+	}
+	if err := a.checkTurn(player); err != nil {
+		return nil, err
+	}
+	cards := len(player.GetCards())
+	if cards == 0 {
+		return nil, fmt.Errorf("%w: %s has no cards to draw to", ErrIllegalAction, player.GetAddress())
+	}
+	return between(big.NewInt(0), big.NewInt(1<<cards-1)), nil
+}
+
+// Execute records the discards. The engine deals the replacements when the
+// turn is added.
+func (a *Draw) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.game.AddTurn(player, types.ActionDraw, amount)
+	return nil
+}
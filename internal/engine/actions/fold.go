@@ -0,0 +1,41 @@
+package actions
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Fold gives up the hand. Folding is allowed even when checking is free.
+type Fold struct {
+	base
+}
+
+// NewFold creates a fold action
+func NewFold(game Game) *Fold {
+	return &Fold{base{game}}
+}
+
+// Type returns types.ActionFold
+func (a *Fold) Type() interface{} {
+	return types.ActionFold
+}
+
+// Verify checks the player may fold
+func (a *Fold) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkBetting(player, types.ActionFold); err != nil {
+		return nil, err
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute folds the player's hand
+func (a *Fold) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	player.SetStatus(types.StatusFolded)
+	a.game.AddTurn(player, types.ActionFold, amount)
+	return nil
+}
@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Muck concedes at showdown without revealing cards. The first player to
+// show down must show.
+type Muck struct {
+	base
+}
+
+// NewMuck creates a muck action
+func NewMuck(game Game) *Muck {
+	return &Muck{base{game}}
+}
+
+// Type returns types.ActionMuck
+func (a *Muck) Type() interface{} {
+	return types.ActionMuck
+}
+
+// Verify checks another player has already shown
+func (a *Muck) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkShowdown(player, types.ActionMuck); err != nil {
+		return nil, err
+	}
+	for _, turn := range a.game.GetTurns(types.RoundShowdown) {
+		if turn.Action == types.ActionShow {
+			return fixed(big.NewInt(0)), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: the first player at showdown must show", ErrIllegalAction)
+}
+
+// Execute mucks the player's cards, giving up any claim to the pot
+func (a *Muck) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	player.SetStatus(types.StatusFolded)
+	a.game.AddTurn(player, types.ActionMuck, amount)
+	return nil
+}
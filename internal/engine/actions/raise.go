@@ -0,0 +1,66 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Raise increases the largest bet. The increase must be at least the size of
+// the last full bet or raise, and a player who has acted since then may only
+// raise again if another full raise has reopened the action.
+type Raise struct {
+	base
+}
+
+// NewRaise creates a raise action
+func NewRaise(game Game) *Raise {
+	return &Raise{base{game}}
+}
+
+// Type returns types.ActionRaise
+func (a *Raise) Type() interface{} {
+	return types.ActionRaise
+}
+
+// Verify returns the range of chips the player may put in. The minimum is
+// the amount to call plus the minimum raise. Pot-limit raises go up to the
+// pot after calling and fixed-limit raises are exactly the call plus the
+// fixed bet.
+func (a *Raise) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkBetting(player, types.ActionRaise); err != nil {
+		return nil, err
+	}
+
+	bets := a.bets()
+	if bets.GetLargestBet().Sign() == 0 {
+		return nil, fmt.Errorf("%w: there is no bet to raise, bet instead", ErrIllegalAction)
+	}
+	if bets.HasActed(player.GetAddress()) {
+		return nil, fmt.Errorf("%w: action was not reopened by a full raise", ErrIllegalAction)
+	}
+
+	minimum, maximum, err := a.raiseRange(bets, player)
+	if err != nil {
+		return nil, err
+	}
+	if player.GetChips().Cmp(minimum) < 0 {
+		return nil, fmt.Errorf("%w: stack of %s is below the minimum raise of %s, go all-in instead",
+			ErrIllegalAction, player.GetChips(), minimum)
+	}
+	if maximum.Cmp(player.GetChips()) > 0 {
+		maximum = player.GetChips()
+	}
+	return between(minimum, maximum), nil
+}
+
+// Execute raises
+func (a *Raise) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.commitChips(player, types.ActionRaise, amount)
+	return nil
+}
@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// RunIt chooses how many times to run the rest of the board once every
+// player is all-in before the river. The amount is a number of boards, not
+// chips, and the board is run as few times as any player chose.
+type RunIt struct {
+	base
+}
+
+// NewRunIt creates a run-it action
+func NewRunIt(game Game) *RunIt {
+	return &RunIt{base{game}}
+}
+
+// Type returns types.ActionRunIt
+func (a *RunIt) Type() interface{} {
+	return types.ActionRunIt
+}
+
+// Verify checks the players are choosing how many times to run the board.
+// The range is from once to the most runs the table and deck allow.
+func (a *RunIt) Verify(player types.IPlayer) (*types.Range, error) {
+	runs := a.game.GetMaxRuns()
+	if runs < 2 {
+		return nil, fmt.Errorf("%w: the board can only be run once", ErrIllegalAction)
+	}
+	if err := a.checkTurn(player); err != nil {
+		return nil, err
+	}
+	return between(big.NewInt(1), big.NewInt(int64(runs))), nil
+}
+
+// Execute records the player's choice
+func (a *RunIt) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.game.AddTurn(player, types.ActionRunIt, amount)
+	return nil
+}
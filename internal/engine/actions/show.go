@@ -0,0 +1,49 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Show reveals the player's hole cards at showdown
+type Show struct {
+	base
+}
+
+// NewShow creates a show action
+func NewShow(game Game) *Show {
+	return &Show{base{game}}
+}
+
+// Type returns types.ActionShow
+func (a *Show) Type() interface{} {
+	return types.ActionShow
+}
+
+// Verify checks the player is due to show
+func (a *Show) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkShowdown(player, types.ActionShow); err != nil {
+		return nil, err
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute shows the player's cards
+func (a *Show) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.game.AddTurn(player, types.ActionShow, amount)
+	return nil
+}
+
+// checkShowdown verifies that it is the player's turn at showdown
+func (b base) checkShowdown(player types.IPlayer, action types.PlayerActionType) error {
+	if round := b.game.GetCurrentRound(); round != types.RoundShowdown {
+		return fmt.Errorf("%w: cannot %s in %s", ErrInvalidRound, action, round)
+	}
+	return b.checkTurn(player)
+}
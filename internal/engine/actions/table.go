@@ -0,0 +1,414 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by non-player actions
+var (
+	ErrInvalidSeat   = errors.New("invalid seat")
+	ErrTableFull     = errors.New("table is full")
+	ErrAlreadySeated = errors.New("player is already seated")
+	ErrNotSeated     = errors.New("player is not seated")
+	ErrHandOptions   = errors.New("invalid hand options")
+)
+
+// Table is the part of a poker engine that non-player actions read and
+// update. Seating rules that depend on the hand in progress, such as
+// folding a player who leaves mid-hand, are left to the engine.
+type Table interface {
+	GetGameFormat() types.GameFormat
+	GetGameVariant() types.GameVariant
+	GetCurrentRound() types.TexasHoldemRound
+	GetActionIndex() int
+	GetMaxPlayers() int
+	GetMinBuyIn() *big.Int
+	GetMaxBuyIn() *big.Int
+	GetPlayerAtSeat(seat int) (types.IPlayer, error)
+	GetPlayerSeatNumber(address string) int
+	AddPlayer(player *models.Player) error
+	RemovePlayer(address string) error
+	IsSittingOut(address string) bool
+	SetSittingOut(address string, out bool) error
+	CanDeal() bool
+	Deal()
+	ReInit(deck string) error
+	SetHandOptions(options types.HandOptions) error
+	AddNonPlayerTurn(player types.IPlayer, action types.NonPlayerActionType, amount *big.Int)
+}
+
+// NewNonPlayerAction returns the action implementing a non-player action
+// type. For JOIN data is the seat, empty for the first free seat; for
+// NEW_HAND it is the deck string, optionally followed by hand options as a
+// query such as "?bombPot=10&boards=2".
+func NewNonPlayerAction(table Table, action types.NonPlayerActionType, data string) (types.IAction, error) {
+	switch action {
+	case types.ActionJoin:
+		seat := 0
+		if data != "" {
+			var err error
+			if seat, err = strconv.Atoi(data); err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidSeat, data)
+			}
+		}
+		return NewJoin(table, seat), nil
+	case types.ActionLeave:
+		return NewLeave(table), nil
+	case types.ActionSitIn:
+		return NewSitIn(table), nil
+	case types.ActionSitOut:
+		return NewSitOut(table), nil
+	case types.ActionDeal:
+		return NewDeal(table), nil
+	case types.ActionNewHand:
+		deck, query, _ := strings.Cut(data, "?")
+		options, err := parseHandOptions(query)
+		if err != nil {
+			return nil, err
+		}
+		return NewNewHand(table, deck, options), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", ErrIllegalAction, action)
+	}
+}
+
+// parseHandOptions reads the bombPot and boards values of a new hand's query
+func parseHandOptions(query string) (types.HandOptions, error) {
+	var options types.HandOptions
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return options, fmt.Errorf("%w: %v", ErrHandOptions, err)
+	}
+	for key := range values {
+		switch value := values.Get(key); key {
+		case "bombPot":
+			ante, ok := new(big.Int).SetString(value, 10)
+			if !ok {
+				return options, fmt.Errorf("%w: bomb pot of %q", ErrHandOptions, value)
+			}
+			options.BombPot = ante
+		case "boards":
+			if options.Boards, err = strconv.Atoi(value); err != nil {
+				return options, fmt.Errorf("%w: %q boards", ErrHandOptions, value)
+			}
+		default:
+			return options, fmt.Errorf("%w: unknown option %q", ErrHandOptions, key)
+		}
+	}
+	return options, nil
+}
+
+// tableBase holds what every non-player action shares
+type tableBase struct {
+	table Table
+}
+
+// checkSeated verifies that the player has a seat
+func (b tableBase) checkSeated(player types.IPlayer) error {
+	if b.table.GetPlayerSeatNumber(player.GetAddress()) == 0 {
+		return fmt.Errorf("%w: %s", ErrNotSeated, player.GetAddress())
+	}
+	return nil
+}
+
+// execute checks the index and amount of an action against its legal range
+// and returns the amount to apply
+func (b tableBase) execute(action types.IAction, player types.IPlayer, index int, amount *big.Int) (*big.Int, error) {
+	if expected := b.table.GetActionIndex(); index != expected {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrInvalidIndex, expected, index)
+	}
+
+	legal, err := action.Verify(player)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+	if amount.Cmp(legal.MinAmount) < 0 || amount.Cmp(legal.MaxAmount) > 0 {
+		return nil, fmt.Errorf("%w: %s must be between %s and %s, got %s",
+			ErrInvalidAmount, action.Type(), legal.MinAmount, legal.MaxAmount, amount)
+	}
+	return amount, nil
+}
+
+// Join seats a player with a buy-in between the table minimum and maximum
+type Join struct {
+	tableBase
+	seat int // 0 for the first free seat
+}
+
+// NewJoin creates a join action for a seat, or the first free seat if seat is 0
+func NewJoin(table Table, seat int) *Join {
+	return &Join{tableBase{table}, seat}
+}
+
+// Type returns types.ActionJoin
+func (a *Join) Type() interface{} {
+	return types.ActionJoin
+}
+
+// Verify checks the seat is free and returns the buy-in range
+func (a *Join) Verify(player types.IPlayer) (*types.Range, error) {
+	if a.table.GetPlayerSeatNumber(player.GetAddress()) != 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadySeated, player.GetAddress())
+	}
+	if _, err := a.resolveSeat(); err != nil {
+		return nil, err
+	}
+	return between(a.table.GetMinBuyIn(), a.table.GetMaxBuyIn()), nil
+}
+
+// Execute seats the player with the buy-in as their stack
+func (a *Join) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+
+	seat, err := a.resolveSeat()
+	if err != nil {
+		return err
+	}
+	seated := models.NewPlayer(player.GetAddress(), new(big.Int).Set(amount), seat)
+	if err := a.table.AddPlayer(seated); err != nil {
+		return err
+	}
+	a.table.AddNonPlayerTurn(seated, types.ActionJoin, amount)
+	return nil
+}
+
+// resolveSeat returns the requested seat if it is free, or the first free seat
+func (a *Join) resolveSeat() (int, error) {
+	max := a.table.GetMaxPlayers()
+	if a.seat != 0 {
+		if a.seat < 1 || a.seat > max {
+			return 0, fmt.Errorf("%w: %d is not between 1 and %d", ErrInvalidSeat, a.seat, max)
+		}
+		if _, err := a.table.GetPlayerAtSeat(a.seat); err == nil {
+			return 0, fmt.Errorf("%w: %d is taken", ErrInvalidSeat, a.seat)
+		}
+		return a.seat, nil
+	}
+
+	for seat := 1; seat <= max; seat++ {
+		if _, err := a.table.GetPlayerAtSeat(seat); err != nil {
+			return seat, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: all %d seats are taken", ErrTableFull, max)
+}
+
+// Leave takes a player off the table with their remaining stack. A player in
+// a hand is folded and their seat is freed when the hand ends. Tournament
+// chips stay in play, so a tournament player cashes out nothing.
+type Leave struct {
+	tableBase
+}
+
+// NewLeave creates a leave action
+func NewLeave(table Table) *Leave {
+	return &Leave{tableBase{table}}
+}
+
+// Type returns types.ActionLeave
+func (a *Leave) Type() interface{} {
+	return types.ActionLeave
+}
+
+// Verify checks the player is seated. Leave takes no amount.
+func (a *Leave) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkSeated(player); err != nil {
+		return nil, err
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute records the player's stack as cashed out at a cash table and
+// removes them
+func (a *Leave) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	if _, err := a.execute(a, player, index, amount); err != nil {
+		return err
+	}
+	cashOut := big.NewInt(0)
+	if a.table.GetGameFormat() == types.FormatCash {
+		cashOut = player.GetChips()
+	}
+	a.table.AddNonPlayerTurn(player, types.ActionLeave, cashOut)
+	return a.table.RemovePlayer(player.GetAddress())
+}
+
+// SitOut stops a player being dealt in from the next hand
+type SitOut struct {
+	tableBase
+}
+
+// NewSitOut creates a sit-out action
+func NewSitOut(table Table) *SitOut {
+	return &SitOut{tableBase{table}}
+}
+
+// Type returns types.ActionSitOut
+func (a *SitOut) Type() interface{} {
+	return types.ActionSitOut
+}
+
+// Verify checks the player is seated and not already sitting out
+func (a *SitOut) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkSeated(player); err != nil {
+		return nil, err
+	}
+	if a.table.IsSittingOut(player.GetAddress()) {
+		return nil, fmt.Errorf("%w: %s is already sitting out", ErrIllegalAction, player.GetAddress())
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute sits the player out
+func (a *SitOut) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	if err := a.table.SetSittingOut(player.GetAddress(), true); err != nil {
+		return err
+	}
+	a.table.AddNonPlayerTurn(player, types.ActionSitOut, amount)
+	return nil
+}
+
+// SitIn returns a sitting-out player to the game
+type SitIn struct {
+	tableBase
+}
+
+// NewSitIn creates a sit-in action
+func NewSitIn(table Table) *SitIn {
+	return &SitIn{tableBase{table}}
+}
+
+// Type returns types.ActionSitIn
+func (a *SitIn) Type() interface{} {
+	return types.ActionSitIn
+}
+
+// Verify checks the player is sitting out and has chips to play with
+func (a *SitIn) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkSeated(player); err != nil {
+		return nil, err
+	}
+	if !a.table.IsSittingOut(player.GetAddress()) {
+		return nil, fmt.Errorf("%w: %s is not sitting out", ErrIllegalAction, player.GetAddress())
+	}
+	if player.GetChips().Sign() == 0 {
+		return nil, fmt.Errorf("%w: %s has no chips", ErrIllegalAction, player.GetAddress())
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute sits the player back in
+func (a *SitIn) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	if err := a.table.SetSittingOut(player.GetAddress(), false); err != nil {
+		return err
+	}
+	a.table.AddNonPlayerTurn(player, types.ActionSitIn, amount)
+	return nil
+}
+
+// Deal deals the hole cards once the blinds are posted. Any seated player
+// may trigger it.
+type Deal struct {
+	tableBase
+}
+
+// NewDeal creates a deal action
+func NewDeal(table Table) *Deal {
+	return &Deal{tableBase{table}}
+}
+
+// Type returns types.ActionDeal
+func (a *Deal) Type() interface{} {
+	return types.ActionDeal
+}
+
+// Verify checks the hole cards are due
+func (a *Deal) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkSeated(player); err != nil {
+		return nil, err
+	}
+	if !a.table.CanDeal() {
+		return nil, fmt.Errorf("%w: cards cannot be dealt in %s", ErrInvalidRound, a.table.GetCurrentRound())
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute deals the hole cards
+func (a *Deal) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	a.table.AddNonPlayerTurn(player, types.ActionDeal, amount)
+	a.table.Deal()
+	return nil
+}
+
+// NewHand starts the next hand from a fresh deck once the current hand is
+// over. The hand may be a bomb pot or dealt with more than one board.
+type NewHand struct {
+	tableBase
+	deck    string
+	options types.HandOptions
+}
+
+// NewNewHand creates a new-hand action that will deal from deck with the
+// given hand options
+func NewNewHand(table Table, deck string, options types.HandOptions) *NewHand {
+	return &NewHand{tableBase{table}, deck, options}
+}
+
+// Type returns types.ActionNewHand
+func (a *NewHand) Type() interface{} {
+	return types.ActionNewHand
+}
+
+// Verify checks the current hand is over and the deck is valid
+func (a *NewHand) Verify(player types.IPlayer) (*types.Range, error) {
+	if err := a.checkSeated(player); err != nil {
+		return nil, err
+	}
+	if round := a.table.GetCurrentRound(); round != types.RoundEnd {
+		return nil, fmt.Errorf("%w: the hand is still in %s", ErrInvalidRound, round)
+	}
+	if _, err := models.NewDeckWithComposition(a.deck, models.CompositionForVariant(a.table.GetGameVariant())); err != nil {
+		return nil, err
+	}
+	return fixed(big.NewInt(0)), nil
+}
+
+// Execute starts the next hand. The new hand is logged first, as starting it
+// may post forced bets for players sitting out of a tournament.
+func (a *NewHand) Execute(player types.IPlayer, index int, amount *big.Int) error {
+	amount, err := a.execute(a, player, index, amount)
+	if err != nil {
+		return err
+	}
+	if err := a.table.SetHandOptions(a.options); err != nil {
+		return err
+	}
+	a.table.AddNonPlayerTurn(player, types.ActionNewHand, amount)
+	return a.table.ReInit(a.deck)
+}
@@ -0,0 +1,332 @@
+package base
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// GetTurns returns the turns recorded in a round of the current hand
+func (g *Game) GetTurns(round types.TexasHoldemRound) []types.Turn {
+	turns := make([]types.Turn, len(g.Turns[round]))
+	for i, turn := range g.Turns[round] {
+		turns[i] = turn.Turn
+	}
+	return turns
+}
+
+// BetManager replays the betting in a round
+func (g *Game) BetManager(round types.TexasHoldemRound) *managers.BetManager {
+	return managers.NewBetManager(g.Options.BigBlind, g.GetTurns(round))
+}
+
+// HasPosted reports whether a forced bet has been posted this hand
+func (g *Game) HasPosted(action types.PlayerActionType) bool {
+	for _, turn := range g.Turns[g.Rounds[0]] {
+		if turn.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Dealt reports whether cards have been dealt this hand
+func (g *Game) Dealt() bool {
+	for _, p := range g.Players {
+		if g.InHand(p) && len(p.HoleCards) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTaken reports whether a player has taken an action in a round
+func (g *Game) HasTaken(round types.TexasHoldemRound, address string, action types.PlayerActionType) bool {
+	for _, turn := range g.Turns[round] {
+		if turn.PlayerID == address && turn.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// HasShown reports whether a player has shown their cards at showdown
+func (g *Game) HasShown(address string) bool {
+	return g.HasTaken(types.RoundShowdown, address, types.ActionShow)
+}
+
+// GetActionIndex returns the index the next action must carry
+func (g *Game) GetActionIndex() int {
+	return g.Index + 1
+}
+
+// GetBets returns each player's chips committed in a round of the current hand
+func (g *Game) GetBets(round types.TexasHoldemRound) map[string]*big.Int {
+	return g.BetManager(round).GetBets()
+}
+
+// GetPot returns all chips committed in the current hand
+func (g *Game) GetPot() *big.Int {
+	pot := big.NewInt(0)
+	for _, turns := range g.Turns {
+		for _, turn := range turns {
+			// Run-it and draw turns count boards and discards, not chips
+			if turn.Amount != nil && turn.Action != types.ActionRunIt && turn.Action != types.ActionDraw {
+				pot.Add(pot, turn.Amount)
+			}
+		}
+	}
+	return pot
+}
+
+// GetPots returns the main pot followed by any side pots of the current hand,
+// with the players eligible to win each. A bet nobody has called is left out
+// until it is returned when the pot is awarded.
+func (g *Game) GetPots() []types.Pot {
+	return g.PotManager().GetPots()
+}
+
+// GetRakeRecords returns the rake taken from each hand played at the table,
+// oldest first. Hands are recorded whenever the table takes rake, including
+// hands where none was due.
+func (g *Game) GetRakeRecords() []types.RakeRecord {
+	return append([]types.RakeRecord(nil), g.Rakes...)
+}
+
+// PotManager splits the bets of the hand into pots contested by the players
+// who have not folded, ordered from the first seat left of the button or, at
+// tables without one, in seat order. Draw rounds hold no bets.
+func (g *Game) PotManager() *managers.PotManager {
+	var contenders []string
+	for _, seat := range g.SeatsFrom(g.button(), g.Contesting) {
+		contenders = append(contenders, g.Players[seat].Address)
+	}
+	var bets []map[string]*big.Int
+	for _, round := range g.Rounds {
+		if !round.IsDraw() {
+			bets = append(bets, g.GetBets(round))
+		}
+	}
+	bets = append(bets, g.BetManager(g.Rounds[0]).GetAntes())
+	return managers.NewPotManager(contenders, bets...)
+}
+
+// GetPlayersLastAction returns the player's most recent turn this hand, or
+// nil if they have not acted yet
+func (g *Game) GetPlayersLastAction(address string) (*types.TurnWithSeat, error) {
+	if g.GetPlayerSeatNumber(address) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, address)
+	}
+
+	var last *types.TurnWithSeat
+	for _, turns := range g.Turns {
+		for i := range turns {
+			if turns[i].PlayerID == address && (last == nil || turns[i].Index > last.Index) {
+				turn := turns[i]
+				last = &turn
+			}
+		}
+	}
+	return last, nil
+}
+
+// GetLastRoundAction returns the most recent turn in the current round
+func (g *Game) GetLastRoundAction() (*types.Turn, error) {
+	turns := g.Turns[g.Round]
+	if len(turns) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoActions, g.Round)
+	}
+	turn := turns[len(turns)-1].Turn
+	return &turn, nil
+}
+
+// BettingComplete reports whether everyone still able to bet has matched the
+// largest bet and acted since the last full raise
+func (g *Game) BettingComplete(round types.TexasHoldemRound) bool {
+	if g.CountPlayers(g.Contesting) <= 1 {
+		return true
+	}
+
+	bets := g.BetManager(round)
+	largest := bets.GetLargestBet()
+	active := 0
+	for _, p := range g.Players {
+		if !g.CanAct(p) {
+			continue
+		}
+		active++
+		if bets.GetBet(p.Address).Cmp(largest) < 0 {
+			return false
+		}
+	}
+
+	// A lone player facing only all-in opponents has nobody left to bet against
+	if active <= 1 {
+		return true
+	}
+	for _, p := range g.Players {
+		if g.CanAct(p) && !bets.HasActed(p.Address) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddTurn records a player's action in the current round. Actions call it
+// once they have been verified and applied to the player.
+func (g *Game) AddTurn(player types.IPlayer, action types.PlayerActionType, amount *big.Int) {
+	g.RecordTurn(player.GetAddress(), action, amount)
+}
+
+// RecordTurn starts the hand if need be and records a player's turn in the
+// current round
+func (g *Game) RecordTurn(address string, action types.PlayerActionType, amount *big.Int) types.TurnWithSeat {
+	if !g.Started() {
+		g.StartHand()
+	}
+	turn := g.LogTurn(address, action, amount)
+	g.Turns[g.Round] = append(g.Turns[g.Round], turn)
+	return turn
+}
+
+// AddNonPlayerTurn records a table action such as a join or deal in the
+// action log. It does not take part in betting.
+func (g *Game) AddNonPlayerTurn(player types.IPlayer, action types.NonPlayerActionType, amount *big.Int) {
+	g.LogTurn(player.GetAddress(), action, amount)
+}
+
+// LogTurn appends a turn with the next index to the action log
+func (g *Game) LogTurn(address string, action interface{}, amount *big.Int) types.TurnWithSeat {
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+
+	g.Index++
+	turn := types.TurnWithSeat{
+		Turn: types.Turn{
+			PlayerID: address,
+			Action:   action,
+			Amount:   new(big.Int).Set(amount),
+			Index:    g.Index,
+		},
+		Seat:      g.GetPlayerSeatNumber(address),
+		Timestamp: g.Now().UnixMilli(),
+	}
+	g.Log = append(g.Log, turn)
+	return turn
+}
+
+// StartHand fixes the button and the players dealt in when the first forced
+// bet is posted, or at the deal when there is none
+func (g *Game) StartHand() {
+	if g.Positions != nil && !g.Positions.HasButton() {
+		g.Positions.HandleNewHand()
+	}
+	for _, p := range g.Players {
+		if eligible(p) {
+			g.Live[p.Address] = true
+		}
+	}
+}
+
+// AwardUncontested returns any uncalled bet and gives the pot, less rake, to
+// the only player who has not folded
+func (g *Game) AwardUncontested() {
+	manager := g.PotManager()
+	g.ReturnUncalled(manager)
+
+	total := big.NewInt(0)
+	for _, pot := range g.TakeRake(manager.GetPots()) {
+		total.Add(total, pot.Amount)
+	}
+	seat := g.NextSeat(0, g.Contesting)
+	winner := g.Players[seat]
+	winner.Chips = new(big.Int).Add(winner.Chips, total)
+
+	g.Winners = []types.Winner{{Amount: total, Name: winner.Address}}
+	g.Round = types.RoundEnd
+}
+
+// TakeRake records the house rake for the hand and returns the pots left to
+// award. Hands that end in the first betting round count as having no flop.
+func (g *Game) TakeRake(pots []types.Pot) []types.Pot {
+	if !g.Rake.Enabled() {
+		return pots
+	}
+
+	rake := g.Rake.Take(pots, len(g.Live), g.Round != g.Rounds[0])
+	record := types.RakeRecord{
+		HandNumber: g.HandNumber,
+		Players:    len(g.Live),
+		Pots:       rake,
+		Total:      big.NewInt(0),
+		Timestamp:  g.Now().UnixMilli(),
+	}
+	raked := make([]types.Pot, len(pots))
+	for i, pot := range pots {
+		raked[i] = types.Pot{Amount: new(big.Int).Sub(pot.Amount, rake[i]), Eligible: pot.Eligible}
+		record.Total.Add(record.Total, rake[i])
+	}
+	g.Rakes = append(g.Rakes, record)
+	return raked
+}
+
+// ReturnUncalled gives the part of a bet nobody called back to the bettor
+func (g *Game) ReturnUncalled(pots *managers.PotManager) {
+	address, amount := pots.GetUncalledBet()
+	if amount.Sign() == 0 {
+		return
+	}
+	if p, err := g.GetPlayer(address); err == nil {
+		p.Chips = new(big.Int).Add(p.Chips, amount)
+	}
+}
+
+// NextHand clears the table for the next hand with a fresh deck once the
+// current hand is over. Players who left cash out, players without chips or
+// who asked to sit out do so, and the button moves on. Variants reset their
+// own state around it.
+func (g *Game) NextHand(deck string) error {
+	if g.Round != types.RoundEnd {
+		return fmt.Errorf("%w: %s", ErrHandInProgress, g.Round)
+	}
+
+	d, err := models.NewDeckWithComposition(deck, models.CompositionForVariant(g.Options.Variant))
+	if err != nil {
+		return err
+	}
+
+	// Players who left during the hand cash out now
+	for seat, p := range g.Players {
+		if g.Leaving[p.Address] {
+			delete(g.Players, seat)
+		}
+	}
+	for _, p := range g.Players {
+		p.HoleCards = make([]types.Card, 0)
+		switch {
+		case p.Status == types.StatusBusted:
+			// Knocked out of a tournament, never dealt in again
+		case p.Chips.Sign() == 0 || g.SittingOut[p.Address]:
+			p.Status = types.StatusSittingOut
+		case p.Status == types.StatusFolded || p.Status == types.StatusAllIn:
+			p.Status = types.StatusActive
+		}
+	}
+
+	g.Deck = d
+	g.Live = make(map[string]bool)
+	g.Leaving = make(map[string]bool)
+	g.SittingOut = make(map[string]bool)
+	g.Round = g.Rounds[0]
+	g.Turns = make(map[types.TexasHoldemRound][]types.TurnWithSeat)
+	g.Winners = nil
+	g.HandNumber++
+	if g.Positions != nil {
+		g.Positions.HandleNewHand()
+	}
+	return nil
+}
@@ -0,0 +1,415 @@
+// Package base holds the table plumbing shared by the poker engines: seating,
+// the action log, betting state, pots and rake. Each engine embeds Game and
+// adds the dealing, forced bets and order of play of its variant.
+package base
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by the game engines
+var (
+	ErrInvalidOptions = errors.New("invalid game options")
+	ErrInvalidSeat    = errors.New("invalid seat")
+	ErrSeatTaken      = errors.New("seat is already taken")
+	ErrAlreadySeated  = errors.New("player is already seated")
+	ErrPlayerNotFound = errors.New("player not found")
+	ErrHandInProgress = errors.New("hand is in progress")
+	ErrSeatingClosed  = errors.New("seating is closed")
+	ErrNoPlayerToAct  = errors.New("no player to act")
+	ErrNoActions      = errors.New("no actions this round")
+)
+
+// Variant is the engine a Game is embedded in. The table asks it for the
+// forced bets of its variant.
+type Variant interface {
+	// GetNextPost returns the forced bet due next this hand, or nil once
+	// every forced bet is in or the hand cannot start
+	GetNextPost() *managers.Post
+}
+
+// Game is the state and rules every poker table shares. A hand is played
+// through Rounds, the first holding the forced bets, and ends in
+// types.RoundEnd once the pot is awarded.
+type Game struct {
+	Options    types.GameOptions
+	Rounds     []types.TexasHoldemRound // Rounds of a hand in the order they are played
+	Deck       *models.Deck
+	Players    map[int]*models.Player          // Keyed by seat, 1 to MaxPlayers
+	Live       map[string]bool                 // Players dealt into the current hand
+	Leaving    map[string]bool                 // Players who left mid-hand, removed when it ends
+	SittingOut map[string]bool                 // Players sitting out from the next hand
+	Positions  *managers.DealerPositionManager // nil at tables without a button
+	Rake       *managers.RakeManager
+	Rakes      []types.RakeRecord // Rake taken from each hand, oldest first
+	Round      types.TexasHoldemRound
+	Turns      map[types.TexasHoldemRound][]types.TurnWithSeat
+	Log        []types.TurnWithSeat // Every turn at the table, including non-player actions
+	Index      int                  // Index of the last recorded turn
+	HandNumber int
+	Winners    []types.Winner
+	Now        func() time.Time
+
+	variant Variant
+}
+
+// New creates an empty table for a variant that will deal its first hand
+// from deck, which must hold exactly the cards of the variant's deck
+// composition. The options must have passed CheckOptions.
+func New(variant Variant, options types.GameOptions, deck string, rounds []types.TexasHoldemRound) (*Game, error) {
+	d, err := models.NewDeckWithComposition(deck, models.CompositionForVariant(options.Variant))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Game{
+		Options:    options,
+		Rounds:     rounds,
+		Deck:       d,
+		Players:    make(map[int]*models.Player),
+		Live:       make(map[string]bool),
+		Leaving:    make(map[string]bool),
+		SittingOut: make(map[string]bool),
+		Rake:       managers.NewRakeManager(options.Rake),
+		Round:      rounds[0],
+		Turns:      make(map[types.TexasHoldemRound][]types.TurnWithSeat),
+		HandNumber: 1,
+		Now:        time.Now,
+		variant:    variant,
+	}, nil
+}
+
+// CheckOptions validates table options and fills in the defaults every
+// variant shares. Variants apply their own restrictions and defaults first.
+func CheckOptions(options *types.GameOptions) error {
+	if options.MaxPlayers < 2 || options.MinPlayers < 2 || options.MinPlayers > options.MaxPlayers {
+		return fmt.Errorf("%w: players must be between 2 and max, got min %d max %d",
+			ErrInvalidOptions, options.MinPlayers, options.MaxPlayers)
+	}
+	if options.SmallBlind == nil || options.BigBlind == nil ||
+		options.SmallBlind.Sign() <= 0 || options.BigBlind.Cmp(options.SmallBlind) < 0 {
+		return fmt.Errorf("%w: blinds must be positive with big blind >= small blind", ErrInvalidOptions)
+	}
+	if options.Ante != nil && options.Ante.Sign() < 0 {
+		return fmt.Errorf("%w: negative ante %s", ErrInvalidOptions, options.Ante)
+	}
+	switch options.Straddle {
+	case types.StraddleNone:
+	case types.StraddleUTG, types.StraddleButton:
+		if options.Format != types.FormatCash {
+			return fmt.Errorf("%w: straddles are only offered in cash games", ErrInvalidOptions)
+		}
+	default:
+		return fmt.Errorf("%w: unknown straddle %q", ErrInvalidOptions, options.Straddle)
+	}
+	if options.MaxRuns < 0 {
+		return fmt.Errorf("%w: negative max runs %d", ErrInvalidOptions, options.MaxRuns)
+	}
+	if options.MaxRuns > 1 && options.Format != types.FormatCash {
+		return fmt.Errorf("%w: running the board more than once is only offered in cash games", ErrInvalidOptions)
+	}
+	switch options.AnteType {
+	case "", types.AnteClassic, types.AnteBigBlind, types.AnteButton:
+	default:
+		return fmt.Errorf("%w: unknown ante type %q", ErrInvalidOptions, options.AnteType)
+	}
+
+	switch options.Betting {
+	case "":
+		options.Betting = types.BettingNoLimit
+	case types.BettingNoLimit, types.BettingPotLimit, types.BettingFixedLimit:
+	default:
+		return fmt.Errorf("%w: unknown betting structure %q", ErrInvalidOptions, options.Betting)
+	}
+	if options.Betting == types.BettingFixedLimit {
+		// Fixed-limit tables default to a small bet of the big blind and a
+		// big bet of twice that, with a bet and three raises a round
+		if options.SmallBet == nil {
+			options.SmallBet = new(big.Int).Set(options.BigBlind)
+		}
+		if options.BigBet == nil {
+			options.BigBet = new(big.Int).Mul(options.SmallBet, big.NewInt(2))
+		}
+		if options.RaiseCap == 0 {
+			options.RaiseCap = 4
+		}
+		if options.SmallBet.Cmp(options.BigBlind) < 0 || options.BigBet.Cmp(options.SmallBet) < 0 {
+			return fmt.Errorf("%w: bets of %s and %s must be at least the big blind and in order",
+				ErrInvalidOptions, options.SmallBet, options.BigBet)
+		}
+		if options.RaiseCap < 0 {
+			return fmt.Errorf("%w: negative raise cap %d", ErrInvalidOptions, options.RaiseCap)
+		}
+	}
+
+	// Cash tables default to buy-ins between 20 and 100 big blinds
+	if options.MinBuyIn == nil {
+		options.MinBuyIn = new(big.Int).Mul(options.BigBlind, big.NewInt(20))
+	}
+	if options.MaxBuyIn == nil {
+		options.MaxBuyIn = new(big.Int).Mul(options.BigBlind, big.NewInt(100))
+	}
+	if options.MinBuyIn.Sign() <= 0 || options.MaxBuyIn.Cmp(options.MinBuyIn) < 0 {
+		return fmt.Errorf("%w: buy-in range %s to %s", ErrInvalidOptions, options.MinBuyIn, options.MaxBuyIn)
+	}
+
+	// The deprecated percentage is converted once to basis points
+	if options.Rake == nil && options.RakePercentage > 0 {
+		options.Rake = &types.RakeOptions{BasisPoints: int(math.Round(options.RakePercentage * 100)), NoFlopNoDrop: true}
+	}
+	if rake := options.Rake; rake != nil {
+		if rake.BasisPoints < 0 || rake.BasisPoints > 10000 {
+			return fmt.Errorf("%w: rake of %d basis points", ErrInvalidOptions, rake.BasisPoints)
+		}
+		for _, amount := range rake.CapsByPlayers {
+			if amount == nil || amount.Sign() < 0 {
+				return fmt.Errorf("%w: negative rake cap", ErrInvalidOptions)
+			}
+		}
+		if rake.Cap != nil && rake.Cap.Sign() < 0 {
+			return fmt.Errorf("%w: negative rake cap", ErrInvalidOptions)
+		}
+	}
+	return nil
+}
+
+// RoundOrder returns the position of a round within a hand
+func (g *Game) RoundOrder(round types.TexasHoldemRound) int {
+	for i, r := range g.Rounds {
+		if r == round {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetGameFormat returns the table format
+func (g *Game) GetGameFormat() types.GameFormat {
+	return g.Options.Format
+}
+
+// GetGameVariant returns the variant being dealt
+func (g *Game) GetGameVariant() types.GameVariant {
+	return g.Options.Variant
+}
+
+// GetSmallBlind returns the small blind
+func (g *Game) GetSmallBlind() *big.Int {
+	return new(big.Int).Set(g.Options.SmallBlind)
+}
+
+// GetBigBlind returns the big blind
+func (g *Game) GetBigBlind() *big.Int {
+	return new(big.Int).Set(g.Options.BigBlind)
+}
+
+// GetBettingStructure returns how much players may bet
+func (g *Game) GetBettingStructure() types.BettingStructure {
+	return g.Options.Betting
+}
+
+// GetRaiseCap returns the most bets and raises allowed in the current round,
+// or 0 for no cap. The cap is lifted once only two players are left in the
+// hand, since neither can be squeezed out.
+func (g *Game) GetRaiseCap() int {
+	if g.Options.Betting != types.BettingFixedLimit || g.CountPlayers(g.Contesting) <= 2 {
+		return 0
+	}
+	return g.Options.RaiseCap
+}
+
+// GetMinPlayers returns the number of players needed to start a hand
+func (g *Game) GetMinPlayers() int {
+	return g.Options.MinPlayers
+}
+
+// GetMaxPlayers returns the number of seats at the table
+func (g *Game) GetMaxPlayers() int {
+	return g.Options.MaxPlayers
+}
+
+// GetCurrentRound returns the round being played
+func (g *Game) GetCurrentRound() types.TexasHoldemRound {
+	return g.Round
+}
+
+// GetHandNumber returns the number of the current hand, starting at 1
+func (g *Game) GetHandNumber() int {
+	return g.HandNumber
+}
+
+// GetDeck returns the deck for the current hand
+func (g *Game) GetDeck() *models.Deck {
+	return g.Deck
+}
+
+// GetWinners returns the winners of the current hand once the pot is awarded
+func (g *Game) GetWinners() []types.Winner {
+	return append([]types.Winner(nil), g.Winners...)
+}
+
+// GetMinBuyIn returns the smallest stack a player may join with
+func (g *Game) GetMinBuyIn() *big.Int {
+	return new(big.Int).Set(g.Options.MinBuyIn)
+}
+
+// GetMaxBuyIn returns the largest stack a player may join with
+func (g *Game) GetMaxBuyIn() *big.Int {
+	return new(big.Int).Set(g.Options.MaxBuyIn)
+}
+
+// GetPlayers returns the seated players in seat order
+func (g *Game) GetPlayers() []*models.Player {
+	seats := make([]int, 0, len(g.Players))
+	for seat := range g.Players {
+		seats = append(seats, seat)
+	}
+	sort.Ints(seats)
+
+	players := make([]*models.Player, len(seats))
+	for i, seat := range seats {
+		players[i] = g.Players[seat]
+	}
+	return players
+}
+
+// GetPlayer returns the seated player with the given address
+func (g *Game) GetPlayer(address string) (*models.Player, error) {
+	for _, p := range g.Players {
+		if p.Address == address {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, address)
+}
+
+// GetPlayerAtSeat returns the player in a seat
+func (g *Game) GetPlayerAtSeat(seat int) (types.IPlayer, error) {
+	if p, ok := g.Players[seat]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%w: no player in seat %d", ErrPlayerNotFound, seat)
+}
+
+// GetPlayerSeatNumber returns the player's seat, or 0 if they are not seated
+func (g *Game) GetPlayerSeatNumber(address string) int {
+	for seat, p := range g.Players {
+		if p.Address == address {
+			return seat
+		}
+	}
+	return 0
+}
+
+// FindActivePlayers returns the players dealt into the current hand, or who
+// will be if it has not started yet, in seat order
+func (g *Game) FindActivePlayers() []types.IPlayer {
+	var players []types.IPlayer
+	for _, p := range g.GetPlayers() {
+		if g.InHand(p) {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// GetLastActedSeat returns the seat of the last turn at the table, or 0
+func (g *Game) GetLastActedSeat() int {
+	if len(g.Log) == 0 {
+		return 0
+	}
+	return g.Log[len(g.Log)-1].Seat
+}
+
+// eligible reports whether a player can be dealt into a new hand. A player
+// whose first turn put them all-in is still dealt in.
+func eligible(p *models.Player) bool {
+	return p.Status != types.StatusSittingOut && p.Status != types.StatusBusted &&
+		(p.Chips.Sign() > 0 || p.Status == types.StatusAllIn)
+}
+
+// Started reports whether the current hand has begun
+func (g *Game) Started() bool {
+	return len(g.Live) > 0
+}
+
+// InHand reports whether a player was dealt into the current hand, or will
+// be if it has not started yet
+func (g *Game) InHand(p *models.Player) bool {
+	if !g.Started() {
+		return eligible(p)
+	}
+	return g.Live[p.Address]
+}
+
+// Contesting reports whether a player is still in the hand and has not folded
+func (g *Game) Contesting(p *models.Player) bool {
+	return g.InHand(p) && p.Status != types.StatusFolded
+}
+
+// CanAct reports whether a player still has chips to bet with in this hand
+func (g *Game) CanAct(p *models.Player) bool {
+	return g.InHand(p) && p.Status == types.StatusActive
+}
+
+// CountPlayers counts the seated players that match a predicate
+func (g *Game) CountPlayers(match func(*models.Player) bool) int {
+	count := 0
+	for _, p := range g.Players {
+		if match(p) {
+			count++
+		}
+	}
+	return count
+}
+
+// NextSeat returns the first seat after from, wrapping around the table,
+// whose player matches. It returns 0 if no player matches.
+func (g *Game) NextSeat(from int, match func(*models.Player) bool) int {
+	for i := 1; i <= g.Options.MaxPlayers; i++ {
+		seat := (from+i-1)%g.Options.MaxPlayers + 1
+		if p, ok := g.Players[seat]; ok && match(p) {
+			return seat
+		}
+	}
+	return 0
+}
+
+// SeatsFrom returns the seats whose players match, starting with the first
+// seat after from. From seat 0 they are in seat order.
+func (g *Game) SeatsFrom(from int, match func(*models.Player) bool) []int {
+	var seats []int
+	for i := 1; i <= g.Options.MaxPlayers; i++ {
+		seat := (from+i-1)%g.Options.MaxPlayers + 1
+		if p, ok := g.Players[seat]; ok && match(p) {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// AddressAt returns the address of the player dealt in at a seat, or an
+// empty string for a dead seat
+func (g *Game) AddressAt(seat int) string {
+	if p, ok := g.Players[seat]; ok && g.InHand(p) {
+		return p.Address
+	}
+	return ""
+}
+
+// button returns the button seat, or 0 at tables without one
+func (g *Game) button() int {
+	if g.Positions == nil {
+		return 0
+	}
+	return g.Positions.GetDealerPosition()
+}
@@ -0,0 +1,123 @@
+package base
+
+import (
+	"fmt"
+
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// GetActionLog returns every turn recorded at the table in index order,
+// including joins, deals and other non-player actions
+func (g *Game) GetActionLog() []types.TurnWithSeat {
+	return append([]types.TurnWithSeat(nil), g.Log...)
+}
+
+// CanDeal reports whether enough players are in, the forced bets are posted
+// and the first cards are due. Dealing passes over an optional post, such as
+// a straddle, that has not been made.
+func (g *Game) CanDeal() bool {
+	if g.Round != g.Rounds[0] || g.Dealt() || g.CountPlayers(g.InHand) < g.Options.MinPlayers {
+		return false
+	}
+	post := g.variant.GetNextPost()
+	return post == nil || post.Optional
+}
+
+// AddPlayer seats a player at player.Seat. Players seated after the first
+// forced bet is posted wait for the next hand. Seating a player between hands
+// updates the button and blinds through the dealer position manager. A
+// Sit-and-Go seats nobody once its first hand has started.
+func (g *Game) AddPlayer(player *models.Player) error {
+	if player.Seat < 1 || player.Seat > g.Options.MaxPlayers {
+		return fmt.Errorf("%w: %d is not between 1 and %d", ErrInvalidSeat, player.Seat, g.Options.MaxPlayers)
+	}
+	if _, ok := g.Players[player.Seat]; ok {
+		return fmt.Errorf("%w: %d", ErrSeatTaken, player.Seat)
+	}
+	if g.GetPlayerSeatNumber(player.Address) != 0 {
+		return fmt.Errorf("%w: %s", ErrAlreadySeated, player.Address)
+	}
+	// A Sit-and-Go is played out by the players who started it
+	if g.Options.Format == types.FormatSitAndGo && (g.Started() || g.HandNumber > 1) {
+		return fmt.Errorf("%w: the Sit-and-Go has started", ErrSeatingClosed)
+	}
+	g.Players[player.Seat] = player
+	if g.Positions != nil && !g.Started() {
+		g.Positions.HandlePlayerJoin(player.Seat)
+	}
+	return nil
+}
+
+// RemovePlayer takes a player off the table. A player holding cards in a
+// hand still being played is folded and keeps their seat until the next
+// hand starts; chips already in the pot stay there.
+func (g *Game) RemovePlayer(address string) error {
+	p, err := g.GetPlayer(address)
+	if err != nil {
+		return err
+	}
+
+	if g.Live[address] && g.Dealt() && g.Round != types.RoundEnd {
+		p.Status = types.StatusFolded
+		g.Leaving[address] = true
+		return nil
+	}
+
+	delete(g.Players, p.Seat)
+	delete(g.Live, address)
+	delete(g.SittingOut, address)
+	if g.Positions != nil && !g.Started() {
+		g.Positions.HandlePlayerLeave(p.Seat)
+	}
+	return nil
+}
+
+// IsSittingOut reports whether a player is sitting out or will sit out from
+// the next hand
+func (g *Game) IsSittingOut(address string) bool {
+	p, err := g.GetPlayer(address)
+	if err != nil {
+		return false
+	}
+	return p.Status == types.StatusSittingOut || g.SittingOut[address]
+}
+
+// SetSittingOut sits a player out or back in. A player in the current hand
+// finishes it and sits out from the next one.
+func (g *Game) SetSittingOut(address string, out bool) error {
+	p, err := g.GetPlayer(address)
+	if err != nil {
+		return err
+	}
+
+	if !out {
+		delete(g.SittingOut, address)
+		if p.Status == types.StatusSittingOut {
+			p.Status = types.StatusActive
+			if g.Positions != nil && !g.Started() {
+				g.Positions.HandlePlayerJoin(p.Seat)
+			}
+		}
+		return nil
+	}
+
+	if g.Live[address] && g.Round != types.RoundEnd {
+		g.SittingOut[address] = true
+		return nil
+	}
+	p.Status = types.StatusSittingOut
+	if g.Positions != nil && !g.Started() {
+		g.Positions.HandlePlayerLeave(p.Seat)
+	}
+	return nil
+}
+
+// SetHandOptions accepts only a standard hand. Bomb pots and extra boards
+// are dealt in community-card games, which override it.
+func (g *Game) SetHandOptions(options types.HandOptions) error {
+	if !options.IsStandard() {
+		return fmt.Errorf("%w: only community-card games deal bomb pots or extra boards", ErrInvalidOptions)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package draw
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/engine/evaluator"
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// PerformAction applies a player's action. The index must equal
+// GetActionIndex and the amount is the number of chips the action moves from
+// the player's stack, within the range given by GetLegalActions. For a draw
+// the amount is a mask of the cards to discard.
+func (g *DrawPoker) PerformAction(address string, action types.PlayerActionType, index int, amount *big.Int) error {
+	player, err := g.GetPlayer(address)
+	if err != nil {
+		return err
+	}
+	if action == types.ActionDraw && amount != nil {
+		if err := g.checkDraw(player, amount); err != nil {
+			return err
+		}
+	}
+	a, err := actions.New(g, action)
+	if err != nil {
+		return err
+	}
+	if err := a.Execute(player, index, amount); err != nil {
+		return err
+	}
+	return g.advance()
+}
+
+// AddTurn records a player's action in the current round. Actions call it
+// once they have been verified and applied to the player. A draw is dealt
+// its replacements as it is recorded.
+func (g *DrawPoker) AddTurn(player types.IPlayer, action types.PlayerActionType, amount *big.Int) {
+	turn := g.RecordTurn(player.GetAddress(), action, amount)
+	if action == types.ActionDraw {
+		// PerformAction has checked the stub, reshuffled if need be, covers
+		// the draw before it is recorded
+		_ = g.replace(g.Players[turn.Seat], amount)
+	}
+}
+
+// Deal deals five cards to each player once the blinds and antes are posted,
+// one card at a time starting left of the button. It does nothing at any
+// other time.
+func (g *DrawPoker) Deal() {
+	if !g.CanDeal() {
+		return
+	}
+
+	seats := g.SeatsFrom(g.GetDealerPosition(), g.InHand)
+	hands := make(map[int][]types.Card, len(seats))
+	for i := 0; i < handSize; i++ {
+		for _, seat := range seats {
+			cards, err := g.Deck.DealHole(seat, 1)
+			if err != nil {
+				return
+			}
+			hands[seat] = append(hands[seat], cards...)
+		}
+	}
+	for seat, cards := range hands {
+		g.Players[seat].HoleCards = cards
+	}
+
+	// The blinds may have put everyone all-in
+	_ = g.advance()
+}
+
+// advance moves the hand on through any rounds that have ended, awarding
+// the pot as needed. Players still draw when nobody is left to bet.
+func (g *DrawPoker) advance() error {
+	for {
+		switch g.Round {
+		case types.RoundShowdown:
+			if !g.HasRoundEnded(g.Round) {
+				return nil
+			}
+			if g.CountPlayers(g.Contesting) == 1 {
+				g.AwardUncontested()
+				return nil
+			}
+			return g.showdown()
+		case types.RoundEnd:
+			return nil
+		default:
+			if g.Started() && g.CountPlayers(g.Contesting) == 1 {
+				g.AwardUncontested()
+				return nil
+			}
+			if !g.HasRoundEnded(g.Round) {
+				return nil
+			}
+			g.Round = g.Rounds[g.RoundOrder(g.Round)+1]
+		}
+	}
+}
+
+// replace discards the player's cards picked by the mask and deals each a
+// replacement in its place. If the stub runs out part way the earlier
+// discards and folded hands are reshuffled into a new stub; the player's own
+// discards join the pile afterwards.
+func (g *DrawPoker) replace(p *models.Player, mask *big.Int) error {
+	hand := append([]types.Card(nil), p.HoleCards...)
+	var discards []types.Card
+	for i := range hand {
+		if mask.Bit(i) == 0 {
+			continue
+		}
+		if g.Deck.Remaining() == 0 {
+			if err := g.reshuffle(); err != nil {
+				return err
+			}
+		}
+		cards, err := g.Deck.DealHole(p.Seat, 1)
+		if err != nil {
+			return err
+		}
+		discards = append(discards, hand[i])
+		hand[i] = cards[0]
+	}
+	p.HoleCards = hand
+	g.discards = append(g.discards, discards...)
+	return nil
+}
+
+// checkDraw makes sure a draw can be dealt its replacements before it is
+// recorded: from the stub or, once that runs out, from a reshuffle of the
+// discards and folded hands, which needs the hand's secret
+func (g *DrawPoker) checkDraw(p *models.Player, mask *big.Int) error {
+	needed := g.discarding(p, mask)
+	if needed <= g.Deck.Remaining() {
+		return nil
+	}
+	if g.secret == nil {
+		return fmt.Errorf("%w: the stub must be reshuffled", ErrNoSecret)
+	}
+	if available := g.Deck.Remaining() + len(g.mucked()); needed > available {
+		return fmt.Errorf("%w: %d cards left to replace %d", ErrIllegalAction, available, needed)
+	}
+	return nil
+}
+
+// discarding counts the player's cards picked by a draw mask
+func (g *DrawPoker) discarding(p *models.Player, mask *big.Int) int {
+	count := 0
+	for i := range p.HoleCards {
+		count += int(mask.Bit(i))
+	}
+	return count
+}
+
+// reshuffle shuffles the discards and the mucked hands of folded players into
+// a new stub. The seed is derived from the hand's secret and the number of
+// reshuffles so far, so every node holding the secret reshuffles alike but
+// players cannot tell where a discard lands.
+func (g *DrawPoker) reshuffle() error {
+	cards := g.mucked()
+	if len(cards) == 0 {
+		return fmt.Errorf("no discards to reshuffle")
+	}
+	if g.secret == nil {
+		return ErrNoSecret
+	}
+
+	var count [4]byte
+	binary.BigEndian.PutUint32(count[:], uint32(g.reshuffles))
+	seed := sha256.Sum256(append(append([]byte(nil), g.secret...), count[:]...))
+	if err := g.Deck.Reshuffle(cards, seed[:]); err != nil {
+		return err
+	}
+	for _, p := range g.Players {
+		if g.InHand(p) && p.Status == types.StatusFolded {
+			p.HoleCards = nil
+		}
+	}
+	g.discards = nil
+	g.reshuffles++
+	return nil
+}
+
+// mucked returns the discards and the hands of folded players, which a
+// reshuffle forms the new stub from
+func (g *DrawPoker) mucked() []types.Card {
+	cards := append([]types.Card(nil), g.discards...)
+	for _, p := range g.Players {
+		if g.InHand(p) && p.Status == types.StatusFolded {
+			cards = append(cards, p.HoleCards...)
+		}
+	}
+	return cards
+}
+
+// showdown evaluates the shown hands and pays each pot, less rake, to the
+// best hands eligible for it: the best high hand in Five-card Draw and the
+// best 2-7 low in Triple Draw. Odd chips go to the first winner left of the
+// button. Winners are listed pot by pot, starting with the main pot.
+func (g *DrawPoker) showdown() error {
+	contenders, err := g.contenders()
+	if err != nil {
+		return err
+	}
+
+	manager := g.PotManager()
+	g.ReturnUncalled(manager)
+
+	g.Winners = nil
+	for _, pot := range g.TakeRake(manager.GetPots()) {
+		eligible := make([]managers.Contender, 0, len(pot.Eligible))
+		for _, c := range contenders {
+			for _, address := range pot.Eligible {
+				if c.Address == address {
+					eligible = append(eligible, c)
+				}
+			}
+		}
+		if g.Options.Variant == types.VariantTripleDraw {
+			g.Winners = append(g.Winners, managers.AwardLow(pot.Amount, eligible)...)
+		} else {
+			g.Winners = append(g.Winners, managers.AwardHigh(pot.Amount, eligible)...)
+		}
+	}
+	for _, winner := range g.Winners {
+		p, err := g.GetPlayer(winner.Name)
+		if err != nil {
+			return err
+		}
+		p.Chips = new(big.Int).Add(p.Chips, winner.Amount)
+	}
+
+	g.Round = types.RoundEnd
+	return nil
+}
+
+// contenders evaluates the hand of each player still in, ordered from the
+// first seat left of the button so odd chips go to them
+func (g *DrawPoker) contenders() ([]managers.Contender, error) {
+	var contenders []managers.Contender
+	for _, seat := range g.SeatsFrom(g.GetDealerPosition(), g.Contesting) {
+		p := g.Players[seat]
+		contender := managers.Contender{Address: p.Address}
+		if g.Options.Variant == types.VariantTripleDraw {
+			low, err := evaluator.EvaluateDeuceToSeven(p.HoleCards)
+			if err != nil {
+				return nil, fmt.Errorf("evaluating seat %d: %w", seat, err)
+			}
+			contender.Low = &low
+		} else {
+			high, err := evaluator.Evaluate(p.HoleCards)
+			if err != nil {
+				return nil, fmt.Errorf("evaluating seat %d: %w", seat, err)
+			}
+			contender.High = high
+		}
+		contenders = append(contenders, contender)
+	}
+	return contenders, nil
+}
+
+// ReInit starts the next hand with a fresh deck once the current hand is
+// over. The big blind moves to the next player who can be dealt in and the
+// button follows under the dead button rule.
+func (g *DrawPoker) ReInit(deck string) error {
+	if err := g.NextHand(deck); err != nil {
+		return err
+	}
+	g.discards = nil
+	g.secret = nil
+	g.reshuffles = 0
+	return nil
+}
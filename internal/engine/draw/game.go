@@ -0,0 +1,344 @@
+package draw
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/engine/base"
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by the game engine
+var (
+	ErrInvalidOptions = base.ErrInvalidOptions
+	ErrInvalidSeat    = base.ErrInvalidSeat
+	ErrSeatTaken      = base.ErrSeatTaken
+	ErrAlreadySeated  = base.ErrAlreadySeated
+	ErrPlayerNotFound = base.ErrPlayerNotFound
+	ErrNotYourTurn    = actions.ErrNotYourTurn
+	ErrInvalidIndex   = actions.ErrInvalidIndex
+	ErrIllegalAction  = actions.ErrIllegalAction
+	ErrInvalidAmount  = actions.ErrInvalidAmount
+	ErrHandInProgress = base.ErrHandInProgress
+	ErrNoPlayerToAct  = base.ErrNoPlayerToAct
+	ErrNoActions      = base.ErrNoActions
+	ErrNoSecret       = errors.New("no reshuffle secret for the hand")
+)
+
+var (
+	_ types.IPoker  = (*DrawPoker)(nil)
+	_ types.IDealer = (*DrawPoker)(nil)
+)
+
+// maxPlayers is the most players a draw table seats. With eight players in
+// the hand the stub and discards still cover a full draw for everyone.
+const maxPlayers = 8
+
+// handSize is the number of cards each player holds
+const handSize = 5
+
+// fiveCardRounds lists the rounds of a Five-card Draw hand in order
+var fiveCardRounds = []types.TexasHoldemRound{
+	types.RoundPreDraw,
+	types.RoundFirstDraw,
+	types.RoundPostFirstDraw,
+	types.RoundShowdown,
+	types.RoundEnd,
+}
+
+// tripleDrawRounds lists the rounds of a Triple Draw hand in order
+var tripleDrawRounds = []types.TexasHoldemRound{
+	types.RoundPreDraw,
+	types.RoundFirstDraw,
+	types.RoundPostFirstDraw,
+	types.RoundSecondDraw,
+	types.RoundPostSecondDraw,
+	types.RoundThirdDraw,
+	types.RoundPostThirdDraw,
+	types.RoundShowdown,
+	types.RoundEnd,
+}
+
+// DrawPoker is a draw poker table implementing types.IPoker. Five-card Draw
+// is played for the best high hand and 2-7 Triple Draw for the best 2-7 low.
+//
+// A hand starts in the pre-draw round with the blinds posted as turns, then
+// Deal gives every player five cards face down and betting begins. Each
+// betting round is followed by a draw, where the players still in discard
+// and are dealt replacements in turn from the left of the button, until the
+// last betting round. The remaining players then show or muck before the
+// pot is awarded and the round moves to END. ReInit starts the next hand.
+//
+// Replacements come from the undealt stub. When it runs out, the earlier
+// discards and the folded hands are shuffled into a new stub, seeded from a
+// secret the dealer supplies for the hand with SetReshuffleSecret.
+type DrawPoker struct {
+	*base.Game
+	blinds     *managers.BlindsManager
+	discards   []types.Card // Cards discarded since the stub was last reshuffled
+	secret     []byte       // Dealer's entropy for reshuffling this hand, kept from players
+	reshuffles int          // Times the stub has been reshuffled this hand
+}
+
+// NewDrawPoker creates an empty table that will deal its first hand from
+// deck. The variant defaults to Five-card Draw and betting to fixed-limit.
+func NewDrawPoker(options types.GameOptions, deck string) (*DrawPoker, error) {
+	if options.MaxPlayers > maxPlayers {
+		return nil, fmt.Errorf("%w: at most %d players, got max %d", ErrInvalidOptions, maxPlayers, options.MaxPlayers)
+	}
+
+	var rounds []types.TexasHoldemRound
+	switch options.Variant {
+	case "":
+		options.Variant = types.VariantFiveCardDraw
+		rounds = fiveCardRounds
+	case types.VariantFiveCardDraw:
+		rounds = fiveCardRounds
+	case types.VariantTripleDraw:
+		rounds = tripleDrawRounds
+	default:
+		return nil, fmt.Errorf("%w: %s is not a draw variant", ErrInvalidOptions, options.Variant)
+	}
+	if options.Straddle != types.StraddleNone || options.MaxRuns > 1 {
+		return nil, fmt.Errorf("%w: draw games have no straddles and are only run once", ErrInvalidOptions)
+	}
+	if options.Betting == "" {
+		options.Betting = types.BettingFixedLimit
+	}
+	if err := base.CheckOptions(&options); err != nil {
+		return nil, err
+	}
+
+	g := &DrawPoker{blinds: managers.NewBlindsManager(options)}
+	game, err := base.New(g, options, deck, rounds)
+	if err != nil {
+		return nil, err
+	}
+	g.Game = game
+	g.Positions = managers.NewDealerPositionManager(g)
+	return g, nil
+}
+
+// SetReshuffleSecret supplies the entropy that seeds any reshuffle of the
+// discards this hand. It must come from outside what players can see, such as
+// a dealer secret committed alongside the hand's shuffle and revealed once the
+// hand is over, and is set before the cards are dealt.
+func (g *DrawPoker) SetReshuffleSecret(secret []byte) error {
+	if len(secret) == 0 {
+		return fmt.Errorf("%w: empty secret", ErrNoSecret)
+	}
+	if g.Dealt() {
+		return fmt.Errorf("%w: %s", ErrHandInProgress, g.Round)
+	}
+	g.secret = append([]byte(nil), secret...)
+	return nil
+}
+
+// GetReshuffleCommitment returns the commitment to the hand's reshuffle
+// secret, hex(SHA256(secret)), or an empty string if none has been set
+func (g *DrawPoker) GetReshuffleCommitment() string {
+	if g.secret == nil {
+		return ""
+	}
+	return models.CommitSecret(g.secret)
+}
+
+// GetFixedBet returns the size of a fixed-limit bet or raise in the current
+// round: the small bet in the first half of the betting rounds, the big bet
+// after. It is nil at tables without fixed-limit betting.
+func (g *DrawPoker) GetFixedBet() *big.Int {
+	if g.Options.Betting != types.BettingFixedLimit {
+		return nil
+	}
+	switch {
+	case g.Round == types.RoundPreDraw,
+		g.Round == types.RoundPostFirstDraw && g.Options.Variant == types.VariantTripleDraw:
+		return new(big.Int).Set(g.Options.SmallBet)
+	default:
+		return new(big.Int).Set(g.Options.BigBet)
+	}
+}
+
+// GetMaxRuns returns 0. Draw hands are never run more than once.
+func (g *DrawPoker) GetMaxRuns() int {
+	return 0
+}
+
+// GetCommunityCards returns no cards. Draw games have no board.
+func (g *DrawPoker) GetCommunityCards() []types.Card {
+	return nil
+}
+
+// GetDealerPosition returns the button seat
+func (g *DrawPoker) GetDealerPosition() int {
+	return g.Positions.GetDealerPosition()
+}
+
+// GetSmallBlindPosition returns the small blind seat
+func (g *DrawPoker) GetSmallBlindPosition() int {
+	return g.Positions.GetSmallBlindPosition()
+}
+
+// GetBigBlindPosition returns the big blind seat
+func (g *DrawPoker) GetBigBlindPosition() int {
+	return g.Positions.GetBigBlindPosition()
+}
+
+// GetNextPost returns the blind or ante due next this hand, or nil once every
+// forced bet is in or the hand cannot start
+func (g *DrawPoker) GetNextPost() *managers.Post {
+	if g.Round != types.RoundPreDraw || g.Dealt() || g.CountPlayers(g.InHand) < g.Options.MinPlayers {
+		return nil
+	}
+
+	var players []types.IPlayer
+	for _, seat := range g.SeatsFrom(g.GetDealerPosition(), g.InHand) {
+		players = append(players, g.Players[seat])
+	}
+	return g.blinds.GetNextPost(players, g.AddressAt(g.GetDealerPosition()),
+		g.AddressAt(g.GetSmallBlindPosition()), g.AddressAt(g.GetBigBlindPosition()), g.GetTurns(types.RoundPreDraw))
+}
+
+// GetNextPlayerToAct returns the player whose turn it is
+func (g *DrawPoker) GetNextPlayerToAct() (types.IPlayer, error) {
+	seat, err := g.nextToActSeat()
+	if err != nil {
+		return nil, err
+	}
+	return g.Players[seat], nil
+}
+
+// nextToActSeat finds the seat of the player whose turn it is
+func (g *DrawPoker) nextToActSeat() (int, error) {
+	switch g.Round {
+	case types.RoundPreDraw:
+		if g.CountPlayers(g.InHand) < g.Options.MinPlayers {
+			return 0, fmt.Errorf("%w: waiting for %d players", ErrNoPlayerToAct, g.Options.MinPlayers)
+		}
+		if post := g.GetNextPost(); post != nil {
+			return g.GetPlayerSeatNumber(post.Address), nil
+		}
+		if !g.Dealt() {
+			return 0, fmt.Errorf("%w: waiting for the deal", ErrNoPlayerToAct)
+		}
+		return g.nextBettor()
+	case types.RoundPostFirstDraw, types.RoundPostSecondDraw, types.RoundPostThirdDraw:
+		return g.nextBettor()
+	case types.RoundFirstDraw, types.RoundSecondDraw, types.RoundThirdDraw:
+		return g.nextTaking(types.ActionDraw)
+	case types.RoundShowdown:
+		return g.nextTaking(types.ActionShow)
+	default:
+		return 0, fmt.Errorf("%w: hand is over", ErrNoPlayerToAct)
+	}
+}
+
+// nextTaking finds the first player left of the button still in the hand
+// who has not yet taken an action this round, such as drawing or showing
+func (g *DrawPoker) nextTaking(action types.PlayerActionType) (int, error) {
+	seat := g.NextSeat(g.GetDealerPosition(), func(p *models.Player) bool {
+		return g.Contesting(p) && !g.HasTaken(g.Round, p.Address, action)
+	})
+	if seat == 0 {
+		return 0, fmt.Errorf("%w: %s complete", ErrNoPlayerToAct, g.Round)
+	}
+	return seat, nil
+}
+
+// nextBettor finds the next player who must act in the current betting round,
+// starting after the last player to act or, with no action yet, after the
+// button
+func (g *DrawPoker) nextBettor() (int, error) {
+	if g.HasRoundEnded(g.Round) {
+		return 0, fmt.Errorf("%w: %s betting is complete", ErrNoPlayerToAct, g.Round)
+	}
+
+	// Pre-draw the action starts left of the big blind, whatever order the
+	// blinds and antes were posted in
+	from := g.GetDealerPosition()
+	if g.Round == types.RoundPreDraw {
+		from = g.GetBigBlindPosition()
+	}
+	turns := g.Turns[g.Round]
+	for i := len(turns) - 1; i >= 0; i-- {
+		switch turns[i].Action {
+		case types.ActionSmallBlind, types.ActionBigBlind, types.ActionAnte:
+			continue
+		}
+		from = turns[i].Seat
+		break
+	}
+
+	bets := g.BetManager(g.Round)
+	largest := bets.GetLargestBet()
+	seat := g.NextSeat(from, func(p *models.Player) bool {
+		return g.CanAct(p) && (!bets.HasActed(p.Address) || bets.GetBet(p.Address).Cmp(largest) < 0)
+	})
+	if seat == 0 {
+		return 0, fmt.Errorf("%w: %s betting is complete", ErrNoPlayerToAct, g.Round)
+	}
+	return seat, nil
+}
+
+// HasRoundEnded reports whether a round of the current hand is complete.
+// Earlier rounds have ended and later rounds have not.
+func (g *DrawPoker) HasRoundEnded(round types.TexasHoldemRound) bool {
+	if order, current := g.RoundOrder(round), g.RoundOrder(g.Round); order != current {
+		return order < current
+	}
+
+	switch round {
+	case types.RoundPreDraw, types.RoundPostFirstDraw, types.RoundPostSecondDraw, types.RoundPostThirdDraw:
+		if round == types.RoundPreDraw && !g.Dealt() {
+			return false
+		}
+		return g.BettingComplete(round)
+	case types.RoundFirstDraw, types.RoundSecondDraw, types.RoundThirdDraw:
+		return g.allTaken(types.ActionDraw)
+	case types.RoundShowdown:
+		return g.allTaken(types.ActionShow)
+	default:
+		return true
+	}
+}
+
+// allTaken reports whether every player still in the hand has taken an
+// action this round, or only one player is left
+func (g *DrawPoker) allTaken(action types.PlayerActionType) bool {
+	return g.CountPlayers(func(p *models.Player) bool {
+		return g.Contesting(p) && !g.HasTaken(g.Round, p.Address, action)
+	}) == 0 || g.CountPlayers(g.Contesting) <= 1
+}
+
+// GetLegalActions returns the actions the player may take now, with the
+// chips each would move, or for a draw the masks of cards they may discard
+func (g *DrawPoker) GetLegalActions(address string) ([]types.LegalActionDTO, error) {
+	player, err := g.GetPlayer(address)
+	if err != nil {
+		return nil, err
+	}
+
+	seat, err := g.nextToActSeat()
+	if err != nil {
+		return nil, err
+	}
+	if seat != player.Seat {
+		return nil, fmt.Errorf("%w: %s in seat %d, expected seat %d", ErrNotYourTurn, address, player.Seat, seat)
+	}
+
+	var legal []types.LegalActionDTO
+	for _, action := range actions.PlayerActions {
+		a, err := actions.New(g, action)
+		if err != nil {
+			return nil, err
+		}
+		if r, err := a.Verify(player); err == nil {
+			legal = append(legal, types.LegalActionDTO{Action: action, MinAmount: r.MinAmount, MaxAmount: r.MaxAmount})
+		}
+	}
+	return legal, nil
+}
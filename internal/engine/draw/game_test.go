@@ -0,0 +1,326 @@
+package draw
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/engine/enginetest"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// actUntil has each player to act take the first of the given actions that
+// is legal, until the round changes from the current one. Players drawing stand pat.
+func actUntil(t *testing.T, game *DrawPoker, preferred ...types.PlayerActionType) {
+	t.Helper()
+
+	round := game.GetCurrentRound()
+	for game.GetCurrentRound() == round {
+		next, err := game.GetNextPlayerToAct()
+		if err != nil {
+			t.Fatalf("GetNextPlayerToAct failed: %v", err)
+		}
+		legal, err := game.GetLegalActions(next.GetAddress())
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		played := false
+		for _, action := range preferred {
+			for _, l := range legal {
+				if l.Action == action && !played {
+					enginetest.Act(t, game, next.GetAddress(), action, l.MinAmount.Int64())
+					played = true
+				}
+			}
+		}
+		if !played {
+			t.Fatalf("None of %v legal for %s, got %+v", preferred, next.GetAddress(), legal)
+		}
+	}
+}
+
+// hand returns a player's cards as space separated mnemonics
+func hand(t *testing.T, game *DrawPoker, address string) string {
+	t.Helper()
+
+	p, err := game.GetPlayer(address)
+	if err != nil {
+		t.Fatalf("GetPlayer failed: %v", err)
+	}
+	mnemonics := make([]string, len(p.HoleCards))
+	for i, card := range p.HoleCards {
+		mnemonics[i] = card.Mnemonic
+	}
+	return strings.Join(mnemonics, " ")
+}
+
+// TestTripleDraw plays a three-handed 2-7 Triple Draw hand to showdown
+func TestTripleDraw(t *testing.T) {
+	// Dealt from seat 2, one card at a time: bob K-7-5-3-2, carol 8-6-5-4-3
+	// and alice 9-9-6-4-2, then the stub for the draws
+	deck := enginetest.StackedDeck(t, "KH 8S 9C 7C 6C 9D 5S 5D 6H 3D 4C 4S 2H 3H 2D 4D 8D KC")
+	game := enginetest.NewGame(t, NewDrawPoker, enginetest.Options(types.VariantTripleDraw), deck, 100, 100, 100)
+	enginetest.PostBlinds(t, game)
+
+	t.Run("should bet before the first draw", func(t *testing.T) {
+		enginetest.ExpectNext(t, game, "alice")
+		enginetest.Act(t, game, "alice", types.ActionCall, 2)
+		enginetest.Act(t, game, "bob", types.ActionCall, 1)
+		enginetest.Act(t, game, "carol", types.ActionCheck, 0)
+
+		if game.GetCurrentRound() != types.RoundFirstDraw {
+			t.Fatalf("Expected the first draw, got %s", game.GetCurrentRound())
+		}
+	})
+
+	t.Run("should replace discards in place from the left of the button", func(t *testing.T) {
+		enginetest.ExpectNext(t, game, "bob")
+		legal, err := game.GetLegalActions("bob")
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		if len(legal) != 1 || legal[0].Action != types.ActionDraw || legal[0].MaxAmount.Int64() != 31 {
+			t.Fatalf("Expected only a draw of up to five cards, got %+v", legal)
+		}
+
+		enginetest.Act(t, game, "bob", types.ActionDraw, 1)   // the king
+		enginetest.Act(t, game, "carol", types.ActionDraw, 0) // pat
+		enginetest.Act(t, game, "alice", types.ActionDraw, 3) // the nines
+
+		if got := hand(t, game, "bob"); got != "4D 7C 5S 3D 2H" {
+			t.Errorf("Expected bob to draw 4D for the king, got %s", got)
+		}
+		if got := hand(t, game, "alice"); got != "8D KC 6H 4S 2D" {
+			t.Errorf("Expected alice to draw 8D KC for the nines, got %s", got)
+		}
+		if game.GetCurrentRound() != types.RoundPostFirstDraw || game.GetPot().Int64() != 6 {
+			t.Errorf("Expected betting after the draw with 6 in the pot, got %s with %s", game.GetCurrentRound(), game.GetPot())
+		}
+	})
+
+	t.Run("should bet the big bet after the second draw", func(t *testing.T) {
+		if game.GetFixedBet().Int64() != 2 {
+			t.Errorf("Expected a small bet of 2, got %s", game.GetFixedBet())
+		}
+		enginetest.Act(t, game, "bob", types.ActionBet, 2)
+		enginetest.Act(t, game, "carol", types.ActionCall, 2)
+		enginetest.Act(t, game, "alice", types.ActionCall, 2)
+
+		for _, p := range []string{"bob", "carol", "alice"} {
+			enginetest.Act(t, game, p, types.ActionDraw, 0)
+		}
+		if game.GetCurrentRound() != types.RoundPostSecondDraw || game.GetFixedBet().Int64() != 4 {
+			t.Errorf("Expected a big bet of 4 after the second draw, got %s", game.GetFixedBet())
+		}
+	})
+}
+
+// TestTripleDraw_Showdown tests the best 2-7 low winning the pot
+func TestTripleDraw_Showdown(t *testing.T) {
+	deck := enginetest.StackedDeck(t, "KH 8S 9C 7C 6C 9D 5S 5D 6H 3D 4C 4S 2H 3H 2D 4D 8D KC")
+	game := enginetest.NewGame(t, NewDrawPoker, enginetest.Options(types.VariantTripleDraw), deck, 100, 100, 100)
+	enginetest.PostBlinds(t, game)
+
+	enginetest.Act(t, game, "alice", types.ActionCall, 2)
+	enginetest.Act(t, game, "bob", types.ActionCall, 1)
+	enginetest.Act(t, game, "carol", types.ActionCheck, 0)
+	enginetest.Act(t, game, "bob", types.ActionDraw, 1)
+	enginetest.Act(t, game, "carol", types.ActionDraw, 0)
+	enginetest.Act(t, game, "alice", types.ActionDraw, 3)
+	enginetest.Act(t, game, "bob", types.ActionBet, 2)
+	enginetest.Act(t, game, "carol", types.ActionCall, 2)
+	enginetest.Act(t, game, "alice", types.ActionCall, 2)
+
+	// Everyone stands pat and checks to the showdown
+	for game.GetCurrentRound() != types.RoundShowdown {
+		actUntil(t, game, types.ActionCheck, types.ActionDraw)
+	}
+	for _, p := range []string{"bob", "carol", "alice"} {
+		enginetest.Act(t, game, p, types.ActionShow, 0)
+	}
+
+	winners := game.GetWinners()
+	if len(winners) != 1 || winners[0].Name != "bob" || winners[0].Description != "7-5-4-3-2 Low" {
+		t.Fatalf("Expected bob to win with 7-5-4-3-2, got %+v", winners)
+	}
+	if enginetest.Chips(t, game, "bob") != 108 || enginetest.Chips(t, game, "carol") != 96 || enginetest.Chips(t, game, "alice") != 96 {
+		t.Errorf("Expected stacks 108/96/96, got %d/%d/%d",
+			enginetest.Chips(t, game, "bob"), enginetest.Chips(t, game, "carol"), enginetest.Chips(t, game, "alice"))
+	}
+}
+
+// TestFiveCardDraw_Reshuffle tests reshuffling the discards when eight
+// players draw five cards each
+func TestFiveCardDraw_Reshuffle(t *testing.T) {
+	game := enginetest.NewGame(t, NewDrawPoker, enginetest.Options(types.VariantFiveCardDraw), enginetest.StackedDeck(t, ""),
+		100, 100, 100, 100, 100, 100, 100, 100)
+	if err := game.SetReshuffleSecret([]byte("dealer secret")); err != nil {
+		t.Fatalf("SetReshuffleSecret failed: %v", err)
+	}
+	enginetest.PostBlinds(t, game)
+
+	actUntil(t, game, types.ActionCall, types.ActionCheck)
+	for game.GetCurrentRound() == types.RoundFirstDraw {
+		next, err := game.GetNextPlayerToAct()
+		if err != nil {
+			t.Fatalf("GetNextPlayerToAct failed: %v", err)
+		}
+		enginetest.Act(t, game, next.GetAddress(), types.ActionDraw, 31)
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range game.GetPlayers() {
+		if len(p.HoleCards) != 5 {
+			t.Fatalf("Expected %s to hold five cards, got %d", p.Address, len(p.HoleCards))
+		}
+		for _, card := range p.HoleCards {
+			if seen[card.Mnemonic] {
+				t.Fatalf("Card %s dealt twice", card.Mnemonic)
+			}
+			seen[card.Mnemonic] = true
+		}
+	}
+	// The audit still covers the hands dealt before the reshuffle
+	if log := game.GetDeck().GetAuditLog(); log[0].Destination != models.DestinationHole {
+		t.Errorf("Expected the audit to start with the deal, got %+v", log[0])
+	}
+	if err := models.VerifyAudit(game.GetDeck().Audit()); err != nil {
+		t.Errorf("VerifyAudit failed: %v", err)
+	}
+
+	if game.GetCurrentRound() != types.RoundPostFirstDraw || game.GetFixedBet().Int64() != 4 {
+		t.Fatalf("Expected a big bet after the only draw, got %s in %s", game.GetFixedBet(), game.GetCurrentRound())
+	}
+	actUntil(t, game, types.ActionCheck)
+	for game.GetCurrentRound() == types.RoundShowdown {
+		next, err := game.GetNextPlayerToAct()
+		if err != nil {
+			t.Fatalf("GetNextPlayerToAct failed: %v", err)
+		}
+		enginetest.Act(t, game, next.GetAddress(), types.ActionShow, 0)
+	}
+
+	total := int64(0)
+	for _, w := range game.GetWinners() {
+		total += w.Amount.Int64()
+	}
+	if game.GetCurrentRound() != types.RoundEnd || total != 16 {
+		t.Errorf("Expected the pot of 16 to be awarded, got %d", total)
+	}
+}
+
+// drawFive has eight players call to the draw and each discard every card,
+// reshuffling the stub, and returns the hands they end up with
+func drawFive(t *testing.T, secret string) []string {
+	t.Helper()
+
+	game := enginetest.NewGame(t, NewDrawPoker, enginetest.Options(types.VariantFiveCardDraw), enginetest.StackedDeck(t, ""),
+		100, 100, 100, 100, 100, 100, 100, 100)
+	if err := game.SetReshuffleSecret([]byte(secret)); err != nil {
+		t.Fatalf("SetReshuffleSecret failed: %v", err)
+	}
+	enginetest.PostBlinds(t, game)
+	actUntil(t, game, types.ActionCall, types.ActionCheck)
+	for game.GetCurrentRound() == types.RoundFirstDraw {
+		next, err := game.GetNextPlayerToAct()
+		if err != nil {
+			t.Fatalf("GetNextPlayerToAct failed: %v", err)
+		}
+		enginetest.Act(t, game, next.GetAddress(), types.ActionDraw, 31)
+	}
+
+	var hands []string
+	for seat := 1; seat <= 8; seat++ {
+		hands = append(hands, hand(t, game, enginetest.Address(seat)))
+	}
+	return hands
+}
+
+// TestFiveCardDraw_ReshuffleSecret tests seeding reshuffles from the dealer's
+// secret rather than anything players can see
+func TestFiveCardDraw_ReshuffleSecret(t *testing.T) {
+	t.Run("should reshuffle alike with the same secret", func(t *testing.T) {
+		if a, b := drawFive(t, "a"), drawFive(t, "a"); strings.Join(a, " ") != strings.Join(b, " ") {
+			t.Errorf("Expected the same hands, got %v and %v", a, b)
+		}
+	})
+
+	t.Run("should reshuffle differently with different secrets", func(t *testing.T) {
+		if a, b := drawFive(t, "a"), drawFive(t, "b"); strings.Join(a, " ") == strings.Join(b, " ") {
+			t.Errorf("Expected different stub orders, got %v for both", a)
+		}
+	})
+
+	t.Run("should commit to the secret", func(t *testing.T) {
+		game := enginetest.NewGame(t, NewDrawPoker, enginetest.Options(types.VariantFiveCardDraw), enginetest.StackedDeck(t, ""), 100, 100)
+		if game.GetReshuffleCommitment() != "" {
+			t.Errorf("Expected no commitment before a secret is set")
+		}
+		if err := game.SetReshuffleSecret([]byte("a")); err != nil {
+			t.Fatalf("SetReshuffleSecret failed: %v", err)
+		}
+		if game.GetReshuffleCommitment() != models.CommitSecret([]byte("a")) {
+			t.Errorf("Expected the commitment to the secret, got %s", game.GetReshuffleCommitment())
+		}
+		if err := game.SetReshuffleSecret(nil); !errors.Is(err, ErrNoSecret) {
+			t.Errorf("Expected ErrNoSecret for an empty secret, got %v", err)
+		}
+
+		enginetest.PostBlinds(t, game)
+		if err := game.SetReshuffleSecret([]byte("b")); !errors.Is(err, ErrHandInProgress) {
+			t.Errorf("Expected ErrHandInProgress once dealt, got %v", err)
+		}
+	})
+
+	t.Run("should refuse a draw that needs a reshuffle without a secret", func(t *testing.T) {
+		game := enginetest.NewGame(t, NewDrawPoker, enginetest.Options(types.VariantFiveCardDraw), enginetest.StackedDeck(t, ""),
+			100, 100, 100, 100, 100, 100, 100, 100)
+		enginetest.PostBlinds(t, game)
+		actUntil(t, game, types.ActionCall, types.ActionCheck)
+
+		// Two players drawing five leave two cards in the stub
+		for i := 0; i < 2; i++ {
+			next, _ := game.GetNextPlayerToAct()
+			enginetest.Act(t, game, next.GetAddress(), types.ActionDraw, 31)
+		}
+		next, _ := game.GetNextPlayerToAct()
+		before, index := hand(t, game, next.GetAddress()), game.GetActionIndex()
+		err := game.PerformAction(next.GetAddress(), types.ActionDraw, index, big.NewInt(31))
+		if !errors.Is(err, ErrNoSecret) {
+			t.Errorf("Expected ErrNoSecret, got %v", err)
+		}
+		if hand(t, game, next.GetAddress()) != before || game.GetActionIndex() != index {
+			t.Errorf("Expected the refused draw to leave the hand and log alone")
+		}
+		enginetest.Act(t, game, next.GetAddress(), types.ActionDraw, 3)
+	})
+}
+
+// TestNewDrawPoker tests option validation
+func TestNewDrawPoker(t *testing.T) {
+	for name, change := range map[string]func(*types.GameOptions){
+		"nine players":  func(o *types.GameOptions) { o.MaxPlayers = 9 },
+		"holdem":        func(o *types.GameOptions) { o.Variant = types.VariantTexasHoldem },
+		"straddle":      func(o *types.GameOptions) { o.Straddle = types.StraddleUTG },
+		"running twice": func(o *types.GameOptions) { o.MaxRuns = 2 },
+		"no blinds":     func(o *types.GameOptions) { o.BigBlind = nil },
+	} {
+		options := enginetest.Options(types.VariantTripleDraw)
+		change(&options)
+		if _, err := NewDrawPoker(options, ""); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%s: expected ErrInvalidOptions, got %v", name, err)
+		}
+	}
+
+	options := enginetest.Options(types.VariantFiveCardDraw)
+	options.Variant = ""
+	game, err := NewDrawPoker(options, "")
+	if err != nil {
+		t.Fatalf("NewDrawPoker failed: %v", err)
+	}
+	if game.GetGameVariant() != types.VariantFiveCardDraw || game.GetBettingStructure() != types.BettingFixedLimit {
+		t.Errorf("Expected fixed-limit Five-card Draw by default, got %s %s", game.GetBettingStructure(), game.GetGameVariant())
+	}
+}
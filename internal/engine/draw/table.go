@@ -0,0 +1,34 @@
+package draw
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// PerformNonPlayerAction applies a table action. For JOIN the amount is the
+// buy-in and data the seat, empty for the first free seat; for NEW_HAND data
+// is the deck string for the next hand.
+func (g *DrawPoker) PerformNonPlayerAction(address string, action types.NonPlayerActionType, index int, amount *big.Int, data string) error {
+	var player types.IPlayer
+	if action == types.ActionJoin {
+		player = models.NewPlayer(address, big.NewInt(0), 0)
+	} else {
+		p, err := g.GetPlayer(address)
+		if err != nil {
+			return err
+		}
+		player = p
+	}
+
+	a, err := actions.NewNonPlayerAction(g, action, data)
+	if err != nil {
+		return err
+	}
+	if err := a.Execute(player, index, amount); err != nil {
+		return err
+	}
+	return g.advance()
+}
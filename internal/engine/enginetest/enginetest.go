@@ -0,0 +1,161 @@
+// Package enginetest provides the fixtures shared by the poker engine tests:
+// stacked decks, standard table options, seated tables and helpers that act
+// and check the state of a hand, failing the test on error.
+package enginetest
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Game is the part of an engine the helpers use
+type Game interface {
+	AddPlayer(player *models.Player) error
+	GetPlayer(address string) (*models.Player, error)
+	GetActionIndex() int
+	GetNextPlayerToAct() (types.IPlayer, error)
+	PerformAction(address string, action types.PlayerActionType, index int, amount *big.Int) error
+}
+
+// Table is a Game that posts forced bets before dealing
+type Table interface {
+	Game
+	GetNextPost() *managers.Post
+	Deal()
+}
+
+// StackedDeck returns a deck string with the given mnemonics on top, followed
+// by the rest of a standard deck in order
+func StackedDeck(t testing.TB, top string) string {
+	t.Helper()
+
+	first := strings.Fields(top)
+	used := make(map[string]bool, len(first))
+	for _, mnemonic := range first {
+		used[mnemonic] = true
+	}
+
+	standard, err := models.NewDeck("")
+	if err != nil {
+		t.Fatalf("NewDeck failed: %v", err)
+	}
+	cards := append([]string(nil), first...)
+	for _, mnemonic := range strings.Split(strings.Trim(standard.ToString(), "[]"), "-") {
+		mnemonic = strings.Trim(mnemonic, "[]")
+		if !used[mnemonic] {
+			cards = append(cards, mnemonic)
+		}
+	}
+	cards[0] = "[" + cards[0] + "]"
+	return strings.Join(cards, "-")
+}
+
+// Options returns the options of a cash table for a variant. Stud is dealt
+// 1/2/4 with antes and a bring-in of 1, draw games fixed-limit with blinds
+// 1/2 at eight seats, and community-card games no-limit with blinds 1/2 at
+// nine seats.
+func Options(variant types.GameVariant) types.GameOptions {
+	switch variant {
+	case types.VariantSevenCardStud:
+		return types.GameOptions{
+			Format:     types.FormatCash,
+			Variant:    variant,
+			Ante:       big.NewInt(1),
+			BringIn:    big.NewInt(1),
+			SmallBet:   big.NewInt(2),
+			BigBet:     big.NewInt(4),
+			MinPlayers: 2,
+			MaxPlayers: 8,
+		}
+	case types.VariantFiveCardDraw, types.VariantTripleDraw:
+		return types.GameOptions{
+			Format:     types.FormatCash,
+			Variant:    variant,
+			SmallBlind: big.NewInt(1),
+			BigBlind:   big.NewInt(2),
+			MinPlayers: 2,
+			MaxPlayers: 8,
+		}
+	default:
+		return types.GameOptions{
+			Format:     types.FormatCash,
+			Variant:    variant,
+			SmallBlind: big.NewInt(1),
+			BigBlind:   big.NewInt(2),
+			MinPlayers: 2,
+			MaxPlayers: 9,
+		}
+	}
+}
+
+// NewGame creates a table with an engine's constructor and seats players with
+// the given stacks in seats 1, 2, 3...
+func NewGame[T Game](t *testing.T, newGame func(types.GameOptions, string) (T, error), options types.GameOptions, deck string, stacks ...int64) T {
+	t.Helper()
+
+	game, err := newGame(options, deck)
+	if err != nil {
+		t.Fatalf("Creating the table failed: %v", err)
+	}
+	for i, stack := range stacks {
+		player := models.NewPlayer(Address(i+1), big.NewInt(stack), i+1)
+		if err := game.AddPlayer(player); err != nil {
+			t.Fatalf("AddPlayer failed: %v", err)
+		}
+	}
+	return game
+}
+
+// Address names the player NewGame seats at a seat
+func Address(seat int) string {
+	return []string{"", "alice", "bob", "carol", "dave", "erin", "frank", "grace", "heidi", "ivan"}[seat]
+}
+
+// Act performs an action with the next index, failing the test on error
+func Act(t *testing.T, game Game, address string, action types.PlayerActionType, amount int64) {
+	t.Helper()
+
+	if err := game.PerformAction(address, action, game.GetActionIndex(), big.NewInt(amount)); err != nil {
+		t.Fatalf("%s %s %d failed: %v", address, action, amount, err)
+	}
+}
+
+// PostBlinds posts the blinds and any antes due, leaving a straddle
+// unposted, then deals
+func PostBlinds(t *testing.T, game Table) {
+	t.Helper()
+
+	for post := game.GetNextPost(); post != nil && !post.Optional; post = game.GetNextPost() {
+		Act(t, game, post.Address, post.Action, post.Amount.Int64())
+	}
+	game.Deal()
+}
+
+// ExpectNext fails the test unless address is next to act
+func ExpectNext(t *testing.T, game Game, address string) {
+	t.Helper()
+
+	next, err := game.GetNextPlayerToAct()
+	if err != nil {
+		t.Fatalf("GetNextPlayerToAct failed: %v", err)
+	}
+	if next.GetAddress() != address {
+		t.Fatalf("Expected %s to act, got %s", address, next.GetAddress())
+	}
+}
+
+// Chips returns a player's stack
+func Chips(t *testing.T, game Game, address string) int64 {
+	t.Helper()
+
+	p, err := game.GetPlayer(address)
+	if err != nil {
+		t.Fatalf("GetPlayer failed: %v", err)
+	}
+	return p.Chips.Int64()
+}
@@ -0,0 +1,419 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by the hand evaluator
+var (
+	ErrInvalidCardCount = errors.New("invalid number of cards")
+	ErrDuplicateCard    = errors.New("duplicate card")
+	ErrInvalidCard      = errors.New("invalid card")
+)
+
+// HandCategory is the class of a five-card poker hand, weakest first
+type HandCategory int
+
+const (
+	HighCard HandCategory = iota + 1
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+)
+
+// String returns the display name of the category
+func (c HandCategory) String() string {
+	switch c {
+	case HighCard:
+		return "High Card"
+	case OnePair:
+		return "Pair"
+	case TwoPair:
+		return "Two Pair"
+	case ThreeOfAKind:
+		return "Three of a Kind"
+	case Straight:
+		return "Straight"
+	case Flush:
+		return "Flush"
+	case FullHouse:
+		return "Full House"
+	case FourOfAKind:
+		return "Four of a Kind"
+	case StraightFlush:
+		return "Straight Flush"
+	default:
+		return fmt.Sprintf("HandCategory(%d)", int(c))
+	}
+}
+
+// Score orders hands: a higher score is a better hand and equal scores tie.
+// The category sits in bits 20-23 and the five tiebreak ranks (2-14, ace
+// high) in 4-bit nibbles below it, most significant first.
+type Score uint32
+
+// Hand is the best five-card hand found by an evaluator
+type Hand struct {
+	Category HandCategory
+	Cards    []types.Card // The five cards used, most significant first
+	Score    Score
+	ranks    [5]int // Tiebreak ranks, ace high (a wheel straight tops at 5)
+}
+
+// Compare returns 1 if h beats other, -1 if it loses and 0 on a tie
+func (h Hand) Compare(other Hand) int {
+	switch {
+	case h.Score > other.Score:
+		return 1
+	case h.Score < other.Score:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Mnemonics returns the mnemonics of the cards used, e.g. for types.Winner.Cards
+func (h Hand) Mnemonics() []string {
+	mnemonics := make([]string, len(h.Cards))
+	for i, card := range h.Cards {
+		mnemonics[i] = card.Mnemonic
+	}
+	return mnemonics
+}
+
+// Description returns a human readable name for the hand, suitable for
+// types.Winner.Description, e.g. "Full House, Kings over Sevens"
+func (h Hand) Description() string {
+	r := h.ranks
+	switch h.Category {
+	case StraightFlush:
+		if r[0] == 14 {
+			return "Royal Flush"
+		}
+		return fmt.Sprintf("Straight Flush, %s High", rankName(r[0]))
+	case FourOfAKind:
+		return fmt.Sprintf("Four of a Kind, %s", rankPlural(r[0]))
+	case FullHouse:
+		return fmt.Sprintf("Full House, %s over %s", rankPlural(r[0]), rankPlural(r[3]))
+	case Flush:
+		return fmt.Sprintf("Flush, %s High", rankName(r[0]))
+	case Straight:
+		return fmt.Sprintf("Straight, %s High", rankName(r[0]))
+	case ThreeOfAKind:
+		return fmt.Sprintf("Three of a Kind, %s", rankPlural(r[0]))
+	case TwoPair:
+		return fmt.Sprintf("Two Pair, %s and %s", rankPlural(r[0]), rankPlural(r[2]))
+	case OnePair:
+		return fmt.Sprintf("Pair of %s", rankPlural(r[0]))
+	default:
+		return fmt.Sprintf("High Card, %s", rankName(r[0]))
+	}
+}
+
+// rankNames maps ace-high ranks (2-14) to names
+var rankNames = [15]string{
+	2: "Two", 3: "Three", 4: "Four", 5: "Five", 6: "Six", 7: "Seven", 8: "Eight",
+	9: "Nine", 10: "Ten", 11: "Jack", 12: "Queen", 13: "King", 14: "Ace",
+}
+
+// rankName returns the singular name of an ace-high rank
+func rankName(rank int) string {
+	return rankNames[rank]
+}
+
+// rankPlural returns the plural name of an ace-high rank
+func rankPlural(rank int) string {
+	if rank == 6 {
+		return "Sixes"
+	}
+	return rankNames[rank] + "s"
+}
+
+// aceHigh converts a card rank (1-13, 1=Ace) to 2-14 with the ace high
+func aceHigh(rank int) int {
+	if rank == 1 {
+		return 14
+	}
+	return rank
+}
+
+// rules are the ranking differences between the decks a hand is dealt from
+type rules struct {
+	aceLow             int  // Rank the ace takes at the bottom of a straight
+	flushOverFullHouse bool // A flush beats a full house
+}
+
+var (
+	// standardRules rank hands dealt from a 52-card deck
+	standardRules = rules{aceLow: 1}
+
+	// shortDeckRules rank hands dealt from the 36-card short deck, where the
+	// ace plays low in A-6-7-8-9 and a flush, being rarer, beats a full house
+	shortDeckRules = rules{aceLow: 5, flushOverFullHouse: true}
+)
+
+// order swaps the scores of flushes and full houses when a flush ranks higher
+func (r rules) order(h Hand) Hand {
+	if r.flushOverFullHouse {
+		switch h.Category {
+		case Flush:
+			h.Score += 1 << 20
+		case FullHouse:
+			h.Score -= 1 << 20
+		}
+	}
+	return h
+}
+
+// newHand packs a category and its tiebreak ranks into a Hand
+func newHand(category HandCategory, cards []types.Card, ranks [5]int) Hand {
+	score := Score(category) << 20
+	for i, rank := range ranks {
+		score |= Score(rank) << (4 * (4 - i))
+	}
+	return Hand{Category: category, Cards: cards, Score: score, ranks: ranks}
+}
+
+// validate checks that cards are well formed and unique
+func validate(cards []types.Card) error {
+	var seen uint64
+	for _, card := range cards {
+		if card.Value < 0 || card.Value > 51 || card.Suit < types.SuitClubs || card.Suit > types.SuitSpades ||
+			card.Rank < 1 || card.Rank > 13 {
+			return fmt.Errorf("%w: %q", ErrInvalidCard, card.Mnemonic)
+		}
+		bit := uint64(1) << uint(card.Value)
+		if seen&bit != 0 {
+			return fmt.Errorf("%w: %s", ErrDuplicateCard, card.Mnemonic)
+		}
+		seen |= bit
+	}
+	return nil
+}
+
+// Evaluate ranks the best five-card hand that can be made from 5 to 7 cards,
+// as in Texas Hold'em where any combination of hole and board cards plays.
+func Evaluate(cards []types.Card) (Hand, error) {
+	if len(cards) < 5 || len(cards) > 7 {
+		return Hand{}, fmt.Errorf("%w: expected 5 to 7, got %d", ErrInvalidCardCount, len(cards))
+	}
+	if err := validate(cards); err != nil {
+		return Hand{}, err
+	}
+	return evaluate(cards), nil
+}
+
+// evaluate ranks the best five-card hand from 5 or more valid, unique cards
+// dealt from a standard deck
+func evaluate(cards []types.Card) Hand {
+	return evaluateRules(cards, standardRules)
+}
+
+// evaluateRules ranks the best five-card hand from 5 or more valid, unique
+// cards under the rules of the deck they were dealt from.
+//
+// Rather than scoring every five-card combination it counts ranks and suits
+// once and picks the best category directly, so a seven-card evaluation is a
+// single pass over the cards plus a tiny insertion sort.
+func evaluateRules(cards []types.Card, r rules) Hand {
+	sorted := sortByRank(cards)
+
+	var rankCount [15]int
+	var suitCount [5]int
+	var suitMask [5]uint16
+	var rankMask uint16
+	for _, card := range sorted {
+		rank := aceHigh(card.Rank)
+		rankCount[rank]++
+		suitCount[card.Suit]++
+		suitMask[card.Suit] |= 1 << uint(rank)
+		rankMask |= 1 << uint(rank)
+	}
+
+	// Straight flush
+	flushSuit := types.Suit(0)
+	for suit := types.SuitClubs; suit <= types.SuitSpades; suit++ {
+		if suitCount[suit] >= 5 {
+			flushSuit = suit
+		}
+	}
+	if flushSuit != 0 {
+		if high := straightHigh(suitMask[flushSuit], r.aceLow); high != 0 {
+			return newHand(StraightFlush, straightCards(sorted, high, flushSuit, r.aceLow), [5]int{high})
+		}
+	}
+
+	// Best quads, trips and pairs, highest rank first
+	quad, trip, pair, secondPair := 0, 0, 0, 0
+	for rank := 14; rank >= 2; rank-- {
+		switch rankCount[rank] {
+		case 4:
+			if quad == 0 {
+				quad = rank
+			}
+		case 3:
+			if trip == 0 {
+				trip = rank
+			} else if pair == 0 {
+				// A second set of trips plays as the pair of a full house
+				pair = rank
+			}
+		case 2:
+			if pair == 0 {
+				pair = rank
+			} else if secondPair == 0 {
+				secondPair = rank
+			}
+		}
+	}
+
+	if quad != 0 {
+		used := pick(sorted, 4, quad)
+		kicker := kickers(sorted, 1, quad, 0)
+		return newHand(FourOfAKind, append(used, kicker...), [5]int{quad, quad, quad, quad, aceHigh(kicker[0].Rank)})
+	}
+
+	if trip != 0 && pair != 0 && (flushSuit == 0 || !r.flushOverFullHouse) {
+		used := append(pick(sorted, 3, trip), pick(sorted, 2, pair)...)
+		return r.order(newHand(FullHouse, used, [5]int{trip, trip, trip, pair, pair}))
+	}
+
+	if flushSuit != 0 {
+		used := make([]types.Card, 0, 5)
+		var ranks [5]int
+		for _, card := range sorted {
+			if card.Suit == flushSuit && len(used) < 5 {
+				ranks[len(used)] = aceHigh(card.Rank)
+				used = append(used, card)
+			}
+		}
+		return r.order(newHand(Flush, used, ranks))
+	}
+
+	if high := straightHigh(rankMask, r.aceLow); high != 0 {
+		return newHand(Straight, straightCards(sorted, high, 0, r.aceLow), [5]int{high})
+	}
+
+	if trip != 0 {
+		used := pick(sorted, 3, trip)
+		kicker := kickers(sorted, 2, trip, 0)
+		return newHand(ThreeOfAKind, append(used, kicker...),
+			[5]int{trip, trip, trip, aceHigh(kicker[0].Rank), aceHigh(kicker[1].Rank)})
+	}
+
+	if pair != 0 && secondPair != 0 {
+		used := append(pick(sorted, 2, pair), pick(sorted, 2, secondPair)...)
+		kicker := kickers(sorted, 1, pair, secondPair)
+		return newHand(TwoPair, append(used, kicker...), [5]int{pair, pair, secondPair, secondPair, aceHigh(kicker[0].Rank)})
+	}
+
+	if pair != 0 {
+		used := pick(sorted, 2, pair)
+		kicker := kickers(sorted, 3, pair, 0)
+		return newHand(OnePair, append(used, kicker...), [5]int{
+			pair, pair, aceHigh(kicker[0].Rank), aceHigh(kicker[1].Rank), aceHigh(kicker[2].Rank),
+		})
+	}
+
+	used := kickers(sorted, 5, 0, 0)
+	var ranks [5]int
+	for i, card := range used {
+		ranks[i] = aceHigh(card.Rank)
+	}
+	return newHand(HighCard, used, ranks)
+}
+
+// sortByRank returns a copy of cards ordered by ace-high rank, then suit,
+// descending. Insertion sort beats sort.Slice for seven cards.
+func sortByRank(cards []types.Card) []types.Card {
+	sorted := make([]types.Card, len(cards))
+	copy(sorted, cards)
+	for i := 1; i < len(sorted); i++ {
+		card := sorted[i]
+		j := i
+		for ; j > 0 && rankedAbove(card, sorted[j-1]); j-- {
+			sorted[j] = sorted[j-1]
+		}
+		sorted[j] = card
+	}
+	return sorted
+}
+
+// rankedAbove reports whether a sorts before b in sortByRank order
+func rankedAbove(a, b types.Card) bool {
+	ra, rb := aceHigh(a.Rank), aceHigh(b.Rank)
+	if ra != rb {
+		return ra > rb
+	}
+	return a.Suit > b.Suit
+}
+
+// straightHigh returns the top rank of the best straight in an ace-high rank
+// mask, or 0 if there is none. The ace also plays low as aceLow, for the
+// wheel A-2-3-4-5 or, in the short deck, A-6-7-8-9.
+func straightHigh(mask uint16, aceLow int) int {
+	if mask&(1<<14) != 0 {
+		mask |= 1 << uint(aceLow)
+	}
+	for high := 14; high >= aceLow+4; high-- {
+		run := uint16(0x1f) << uint(high-4)
+		if mask&run == run {
+			return high
+		}
+	}
+	return 0
+}
+
+// straightCards picks one card per rank for the straight topped by high,
+// restricted to a suit unless suit is 0
+func straightCards(sorted []types.Card, high int, suit types.Suit, aceLow int) []types.Card {
+	used := make([]types.Card, 0, 5)
+	for rank := high; rank > high-5; rank-- {
+		want := rank
+		if want == aceLow {
+			want = 14
+		}
+		for _, card := range sorted {
+			if aceHigh(card.Rank) == want && (suit == 0 || card.Suit == suit) {
+				used = append(used, card)
+				break
+			}
+		}
+	}
+	return used
+}
+
+// pick returns the first n cards of the given ace-high rank
+func pick(sorted []types.Card, n int, rank int) []types.Card {
+	used := make([]types.Card, 0, 5)
+	for _, card := range sorted {
+		if aceHigh(card.Rank) == rank && len(used) < n {
+			used = append(used, card)
+		}
+	}
+	return used
+}
+
+// kickers returns the n highest cards whose ace-high rank is neither of the
+// excluded ranks (0 excludes nothing)
+func kickers(sorted []types.Card, n int, exclude1, exclude2 int) []types.Card {
+	used := make([]types.Card, 0, n)
+	for _, card := range sorted {
+		if len(used) == n {
+			break
+		}
+		rank := aceHigh(card.Rank)
+		if rank != exclude1 && rank != exclude2 {
+			used = append(used, card)
+		}
+	}
+	return used
+}
@@ -0,0 +1,139 @@
+package evaluator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// parseCards parses space separated mnemonics such as "AS KD 7C"
+func parseCards(t testing.TB, mnemonics string) []types.Card {
+	t.Helper()
+
+	fields := strings.Fields(mnemonics)
+	cards := make([]types.Card, len(fields))
+	for i, mnemonic := range fields {
+		card, err := models.FromString(mnemonic)
+		if err != nil {
+			t.Fatalf("FromString(%s) failed: %v", mnemonic, err)
+		}
+		cards[i] = card
+	}
+	return cards
+}
+
+// TestEvaluate tests categorisation of five to seven card hands
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cards       string
+		category    HandCategory
+		used        string
+		description string
+	}{
+		{"royal flush", "AS KS QS JS TS 2C 3D", StraightFlush, "AS KS QS JS TS", "Royal Flush"},
+		{"steel wheel", "AH 2H 3H 4H 5H KC KD", StraightFlush, "5H 4H 3H 2H AH", "Straight Flush, Five High"},
+		{"quads with kicker", "9C 9D 9H 9S AC 2D 3H", FourOfAKind, "9S 9H 9D 9C AC", "Four of a Kind, Nines"},
+		{"full house from two trips", "KC KD KH 7S 7C 7D 2H", FullHouse, "KH KD KC 7S 7D", "Full House, Kings over Sevens"},
+		{"full house prefers higher pair", "6C 6D 6H 2S 2C QD QH", FullHouse, "6H 6D 6C QH QD", "Full House, Sixes over Queens"},
+		{"flush over straight", "AD JD 8D 4D 2D 9C TH", Flush, "AD JD 8D 4D 2D", "Flush, Ace High"},
+		{"best five of six suited", "AD JD 8D 4D 2D 3D KC", Flush, "AD JD 8D 4D 3D", "Flush, Ace High"},
+		{"broadway straight", "AC KD QH JS TC 2D 2H", Straight, "AC KD QH JS TC", "Straight, Ace High"},
+		{"wheel", "AC 2D 3H 4S 5C KD 9H", Straight, "5C 4S 3H 2D AC", "Straight, Five High"},
+		{"six-high beats wheel", "AC 2D 3H 4S 5C 6D 9H", Straight, "6D 5C 4S 3H 2D", "Straight, Six High"},
+		{"trips", "7C 7D 7H AS KD 4C 2H", ThreeOfAKind, "7H 7D 7C AS KD", "Three of a Kind, Sevens"},
+		{"two pair from three", "AC AD 8H 8S 4C 4D KH", TwoPair, "AD AC 8S 8H KH", "Two Pair, Aces and Eights"},
+		{"two pair kicker from third pair", "AC AD 8H 8S 4C 4D 2H", TwoPair, "AD AC 8S 8H 4D", "Two Pair, Aces and Eights"},
+		{"pair", "JC JD 9H 7S 4C 3D 2H", OnePair, "JD JC 9H 7S 4C", "Pair of Jacks"},
+		{"high card", "AC JD 9H 7S 4C 3D 2H", HighCard, "AC JD 9H 7S 4C", "High Card, Ace"},
+		{"five cards", "KC QD 9H 7S 4C", HighCard, "KC QD 9H 7S 4C", "High Card, King"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should rank "+tt.name, func(t *testing.T) {
+			hand, err := Evaluate(parseCards(t, tt.cards))
+			if err != nil {
+				t.Fatalf("Evaluate failed: %v", err)
+			}
+
+			if hand.Category != tt.category {
+				t.Errorf("Expected %s, got %s", tt.category, hand.Category)
+			}
+			if used := strings.Join(hand.Mnemonics(), " "); used != tt.used {
+				t.Errorf("Expected cards %s, got %s", tt.used, used)
+			}
+			if hand.Description() != tt.description {
+				t.Errorf("Expected description %q, got %q", tt.description, hand.Description())
+			}
+		})
+	}
+}
+
+// TestHand_Compare tests score ordering between hands
+func TestHand_Compare(t *testing.T) {
+	tests := []struct {
+		name   string
+		better string
+		worse  string
+	}{
+		{"category", "2C 2D 3H 4S 6C", "AC KD QH JS 9C"},
+		{"kicker", "AC AD KH 4S 3C", "AH AS QH JS TC"},
+		{"second pair", "KC KD 9H 9S 2C", "KH KS 8H 8S AC"},
+		{"wheel loses to six high", "2C 3D 4H 5S 6C", "AC 2D 3H 4S 5C"},
+		{"full house trips first", "3C 3D 3H 2S 2C", "2D 2H 2S AC AD"},
+		{"flush cards in order", "AC QC 9C 5C 3C", "AD QD 9D 5D 2D"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should order by "+tt.name, func(t *testing.T) {
+			better, _ := Evaluate(parseCards(t, tt.better))
+			worse, _ := Evaluate(parseCards(t, tt.worse))
+
+			if better.Compare(worse) != 1 || worse.Compare(better) != -1 {
+				t.Errorf("Expected %s to beat %s", tt.better, tt.worse)
+			}
+		})
+	}
+
+	t.Run("should tie identical ranks in different suits", func(t *testing.T) {
+		a, _ := Evaluate(parseCards(t, "AC KD QH JS 9C 2D 3D"))
+		b, _ := Evaluate(parseCards(t, "AD KC QS JH 9D 2C 3C"))
+
+		if a.Compare(b) != 0 {
+			t.Error("Expected a tie")
+		}
+	})
+}
+
+// TestEvaluate_Errors tests input validation
+func TestEvaluate_Errors(t *testing.T) {
+	t.Run("should reject too few and too many cards", func(t *testing.T) {
+		if _, err := Evaluate(parseCards(t, "AC KD QH JS")); !errors.Is(err, ErrInvalidCardCount) {
+			t.Errorf("Expected ErrInvalidCardCount, got %v", err)
+		}
+		if _, err := Evaluate(parseCards(t, "AC KD QH JS 9C 2D 3D 4D")); !errors.Is(err, ErrInvalidCardCount) {
+			t.Errorf("Expected ErrInvalidCardCount, got %v", err)
+		}
+	})
+
+	t.Run("should reject duplicate cards", func(t *testing.T) {
+		if _, err := Evaluate(parseCards(t, "AC AC QH JS 9C")); !errors.Is(err, ErrDuplicateCard) {
+			t.Errorf("Expected ErrDuplicateCard, got %v", err)
+		}
+	})
+}
+
+// BenchmarkEvaluate7 measures seven-card evaluation
+func BenchmarkEvaluate7(b *testing.B) {
+	cards := parseCards(b, "AC KD 7H 7S 4C 4D 2H")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Evaluate(cards); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// LowHand is the best low found by a low evaluator. In ace-to-five lows
+// straights and flushes do not count against a low and the ace plays low.
+// In 2-7 lows they count and the ace plays high.
+type LowHand struct {
+	Cards []types.Card // The five cards used, highest first
+	Score Score        // Higher is a better low; equal scores tie
+	ranks [5]int       // Ranks highest first, ace-low (1-13) or for 2-7 ace-high (2-14)
+	made  *Hand        // For a 2-7 low, the pair, straight or flush it makes
+}
+
+// Compare returns 1 if l is a better low than other, -1 if worse and 0 on a tie
+func (l LowHand) Compare(other LowHand) int {
+	switch {
+	case l.Score > other.Score:
+		return 1
+	case l.Score < other.Score:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Mnemonics returns the mnemonics of the cards used
+func (l LowHand) Mnemonics() []string {
+	mnemonics := make([]string, len(l.Cards))
+	for i, card := range l.Cards {
+		mnemonics[i] = card.Mnemonic
+	}
+	return mnemonics
+}
+
+// Description returns the low written from the top card down, e.g. "8-6-4-2-A Low".
+// A 2-7 low that makes a hand is described by that hand, e.g. "Pair of Eights".
+func (l LowHand) Description() string {
+	if l.made != nil {
+		return l.made.Description()
+	}
+	names := make([]string, 5)
+	for i, rank := range l.ranks {
+		names[i] = string(lowRankChars[rank])
+	}
+	return strings.Join(names, "-") + " Low"
+}
+
+// LowEvaluateFunc finds a player's best qualifying low from hole and board cards
+type LowEvaluateFunc func(hole, board []types.Card) (LowHand, bool, error)
+
+// LowForVariant returns the low evaluator for a split-pot variant, or nil if
+// the variant only awards the high hand
+func LowForVariant(variant types.GameVariant) LowEvaluateFunc {
+	switch variant {
+	case types.VariantOmahaHiLo:
+		return EvaluateOmahaLow
+	default:
+		return nil
+	}
+}
+
+// lowRankChars maps ranks to display characters, ace-low (1-13) or with 14
+// for an ace playing high
+const lowRankChars = " A23456789TJQKA"
+
+// eightOrBetter is the highest card rank a qualifying low may contain
+const eightOrBetter = 8
+
+// newLowHand packs five distinct ace-low ranks, highest first, into a LowHand.
+// The packed ranks are subtracted from the maximum so better lows score higher.
+func newLowHand(cards []types.Card, ranks [5]int) LowHand {
+	var packed Score
+	for i, rank := range ranks {
+		packed |= Score(rank) << (4 * (4 - i))
+	}
+	return LowHand{Cards: cards, Score: Score(1<<20) - packed, ranks: ranks}
+}
+
+// EvaluateLow finds the best eight-or-better ace-to-five low among 5 to 7
+// cards, as in Stud Hi-Lo. The boolean is false when no low qualifies.
+func EvaluateLow(cards []types.Card) (LowHand, bool, error) {
+	if len(cards) < 5 || len(cards) > 7 {
+		return LowHand{}, false, fmt.Errorf("%w: expected 5 to 7, got %d", ErrInvalidCardCount, len(cards))
+	}
+	if err := validate(cards); err != nil {
+		return LowHand{}, false, err
+	}
+	low, ok := evaluateLow(cards)
+	return low, ok, nil
+}
+
+// EvaluateOmahaLow finds the best eight-or-better low using exactly two hole
+// cards and three board cards, as in Omaha Hi-Lo
+func EvaluateOmahaLow(hole, board []types.Card) (LowHand, bool, error) {
+	if len(hole) < 4 || len(hole) > 6 {
+		return LowHand{}, false, fmt.Errorf("%w: expected 4 to 6 hole cards, got %d", ErrInvalidCardCount, len(hole))
+	}
+	if len(board) < 3 || len(board) > 5 {
+		return LowHand{}, false, fmt.Errorf("%w: expected 3 to 5 board cards, got %d", ErrInvalidCardCount, len(board))
+	}
+	all := make([]types.Card, 0, len(hole)+len(board))
+	all = append(all, hole...)
+	all = append(all, board...)
+	if err := validate(all); err != nil {
+		return LowHand{}, false, err
+	}
+
+	var best LowHand
+	found := false
+	five := make([]types.Card, 5)
+	for h1 := 0; h1 < len(hole); h1++ {
+		for h2 := h1 + 1; h2 < len(hole); h2++ {
+			five[0], five[1] = hole[h1], hole[h2]
+			for b1 := 0; b1 < len(board); b1++ {
+				for b2 := b1 + 1; b2 < len(board); b2++ {
+					for b3 := b2 + 1; b3 < len(board); b3++ {
+						five[2], five[3], five[4] = board[b1], board[b2], board[b3]
+						if low, ok := evaluateLow(five); ok && (!found || low.Score > best.Score) {
+							best, found = low, true
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, found, nil
+}
+
+// EvaluateDeuceToSeven ranks exactly five cards as a 2-7 low, as in 2-7
+// Triple Draw. The ace only plays high, straights and flushes count against
+// the hand and the best low is 7-5-4-3-2 of mixed suits. Every hand
+// qualifies, and of two hands the worse high hand is the better low.
+func EvaluateDeuceToSeven(cards []types.Card) (LowHand, error) {
+	if len(cards) != 5 {
+		return LowHand{}, fmt.Errorf("%w: expected 5, got %d", ErrInvalidCardCount, len(cards))
+	}
+	if err := validate(cards); err != nil {
+		return LowHand{}, err
+	}
+
+	hand := evaluate(cards)
+	if (hand.Category == Straight || hand.Category == StraightFlush) && hand.ranks[0] == 5 {
+		// Without a low ace A-5-4-3-2 is only ace high
+		category := HighCard
+		if hand.Category == StraightFlush {
+			category = Flush
+		}
+		hand = newHand(category, sortByRank(cards), [5]int{14, 5, 4, 3, 2})
+	}
+
+	// The high score fits in 24 bits, so subtracting reverses the order
+	low := LowHand{Cards: hand.Cards, Score: Score(1<<24) - hand.Score, ranks: hand.ranks}
+	if hand.Category != HighCard {
+		low.made = &hand
+	}
+	return low, nil
+}
+
+// evaluateLow picks the five lowest distinct ranks of eight or below
+func evaluateLow(cards []types.Card) (LowHand, bool) {
+	var byRank [eightOrBetter + 1]*types.Card
+	for i := range cards {
+		if rank := cards[i].Rank; rank <= eightOrBetter && byRank[rank] == nil {
+			byRank[rank] = &cards[i]
+		}
+	}
+
+	used := make([]types.Card, 0, 5)
+	var ascending [5]int
+	for rank := 1; rank <= eightOrBetter && len(used) < 5; rank++ {
+		if byRank[rank] != nil {
+			ascending[len(used)] = rank
+			used = append(used, *byRank[rank])
+		}
+	}
+	if len(used) < 5 {
+		return LowHand{}, false
+	}
+
+	// Compare lows from the highest card down
+	var ranks [5]int
+	cardsDesc := make([]types.Card, 5)
+	for i := 0; i < 5; i++ {
+		ranks[i] = ascending[4-i]
+		cardsDesc[i] = used[4-i]
+	}
+	return newLowHand(cardsDesc, ranks), true
+}
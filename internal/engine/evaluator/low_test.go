@@ -0,0 +1,140 @@
+package evaluator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestEvaluateLow tests eight-or-better ace-to-five lows
+func TestEvaluateLow(t *testing.T) {
+	tests := []struct {
+		name        string
+		cards       string
+		qualifies   bool
+		used        string
+		description string
+	}{
+		{"wheel despite straight", "AC 2D 3H 4S 5C KD KH", true, "5C 4S 3H 2D AC", "5-4-3-2-A Low"},
+		{"wheel despite flush", "AH 2H 3H 4H 5H", true, "5H 4H 3H 2H AH", "5-4-3-2-A Low"},
+		{"pairs are skipped", "AC AD 2H 3S 3C 6D 8H", true, "8H 6D 3S 2H AC", "8-6-3-2-A Low"},
+		{"rough eight", "8C 7D 6H 5S 4C KD QH", true, "8C 7D 6H 5S 4C", "8-7-6-5-4 Low"},
+		{"nine does not qualify", "9C 7D 6H 5S 4C KD QH", false, "", ""},
+		{"four low cards do not qualify", "AC 2D 3H 4S 4C KD QH", false, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run("should evaluate "+tt.name, func(t *testing.T) {
+			low, ok, err := EvaluateLow(parseCards(t, tt.cards))
+			if err != nil {
+				t.Fatalf("EvaluateLow failed: %v", err)
+			}
+			if ok != tt.qualifies {
+				t.Fatalf("Expected qualifies=%v, got %v", tt.qualifies, ok)
+			}
+			if !ok {
+				return
+			}
+			if used := strings.Join(low.Mnemonics(), " "); used != tt.used {
+				t.Errorf("Expected cards %s, got %s", tt.used, used)
+			}
+			if low.Description() != tt.description {
+				t.Errorf("Expected %q, got %q", tt.description, low.Description())
+			}
+		})
+	}
+
+	t.Run("should compare from the top card down", func(t *testing.T) {
+		better, _, _ := EvaluateLow(parseCards(t, "7C 5D 4H 3S 2C"))
+		worse, _, _ := EvaluateLow(parseCards(t, "7C 6D 3H 2S AC"))
+
+		if better.Compare(worse) != 1 || worse.Compare(better) != -1 {
+			t.Error("Expected 7-5-4-3-2 to beat 7-6-3-2-A")
+		}
+	})
+}
+
+// TestEvaluateOmahaLow tests the two-hole-card rule for lows
+func TestEvaluateOmahaLow(t *testing.T) {
+	t.Run("should use exactly two hole cards", func(t *testing.T) {
+		low, ok, err := EvaluateOmahaLow(parseCards(t, "AC 2D KH KS"), parseCards(t, "3C 4D 5H QS JD"))
+		if err != nil {
+			t.Fatalf("EvaluateOmahaLow failed: %v", err)
+		}
+		if !ok || low.Description() != "5-4-3-2-A Low" {
+			t.Errorf("Expected a wheel, got %v %s", ok, low.Description())
+		}
+	})
+
+	t.Run("should not qualify with one low hole card", func(t *testing.T) {
+		_, ok, err := EvaluateOmahaLow(parseCards(t, "AC KD KH KS"), parseCards(t, "2C 3D 4H 5S JD"))
+		if err != nil {
+			t.Fatalf("EvaluateOmahaLow failed: %v", err)
+		}
+		if ok {
+			t.Error("Expected no qualifying low")
+		}
+	})
+
+	t.Run("should not qualify with fewer than three low board cards", func(t *testing.T) {
+		_, ok, _ := EvaluateOmahaLow(parseCards(t, "AC 2D 3H 4S"), parseCards(t, "5C 9D TH JS QD"))
+		if ok {
+			t.Error("Expected no qualifying low")
+		}
+	})
+}
+
+// TestEvaluateDeuceToSeven tests 2-7 lows, where aces are high and
+// straights and flushes count against the hand
+func TestEvaluateDeuceToSeven(t *testing.T) {
+	tests := []struct {
+		name        string
+		cards       string
+		description string
+	}{
+		{"the nuts", "7C 5D 4H 3S 2C", "7-5-4-3-2 Low"},
+		{"a high ace", "AC 5D 4H 3S 2C", "A-5-4-3-2 Low"},
+		{"a straight", "6C 5D 4H 3S 2C", "Straight, Six High"},
+		{"a flush", "7H 5H 4H 3H 2H", "Flush, Seven High"},
+		{"a pair", "8C 8D 4H 3S 2C", "Pair of Eights"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should describe "+tt.name, func(t *testing.T) {
+			low, err := EvaluateDeuceToSeven(parseCards(t, tt.cards))
+			if err != nil {
+				t.Fatalf("EvaluateDeuceToSeven failed: %v", err)
+			}
+			if low.Description() != tt.description {
+				t.Errorf("Expected %q, got %q", tt.description, low.Description())
+			}
+		})
+	}
+
+	t.Run("should rank lows from best to worst", func(t *testing.T) {
+		ordered := []string{
+			"7C 5D 4H 3S 2C",
+			"7C 6D 4H 3S 2C",
+			"8C 5D 4H 3S 2C",
+			"KC QD JH TS 8C",
+			"AC 5D 4H 3S 2C",
+			"2C 2D 7H 5S 4C",
+			"AC AD KH QS JC",
+			"6C 5D 4H 3S 2C",
+			"7H 5H 4H 3H 2H",
+		}
+		for i := 0; i+1 < len(ordered); i++ {
+			better, _ := EvaluateDeuceToSeven(parseCards(t, ordered[i]))
+			worse, _ := EvaluateDeuceToSeven(parseCards(t, ordered[i+1]))
+			if better.Compare(worse) != 1 {
+				t.Errorf("Expected %s to beat %s", ordered[i], ordered[i+1])
+			}
+		}
+	})
+
+	t.Run("should only take five cards", func(t *testing.T) {
+		if _, err := EvaluateDeuceToSeven(parseCards(t, "7C 5D 4H 3S 2C KD")); !errors.Is(err, ErrInvalidCardCount) {
+			t.Errorf("Expected ErrInvalidCardCount, got %v", err)
+		}
+	})
+}
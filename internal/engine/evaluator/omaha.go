@@ -0,0 +1,86 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// EvaluateFunc ranks a player's best hand from their hole cards and the board.
+// Every variant returns the same Hand so showdown code can treat them alike.
+type EvaluateFunc func(hole, board []types.Card) (Hand, error)
+
+// ForVariant returns the evaluator for a community-card game variant
+func ForVariant(variant types.GameVariant) EvaluateFunc {
+	switch variant {
+	case types.VariantOmaha, types.VariantOmahaHiLo:
+		return EvaluateOmaha
+	case types.VariantShortDeck:
+		return EvaluateShortDeck
+	default:
+		return EvaluateHoldem
+	}
+}
+
+// EvaluateHoldem ranks the best five-card hand from hole and board cards,
+// where any combination of the two may play
+func EvaluateHoldem(hole, board []types.Card) (Hand, error) {
+	cards := make([]types.Card, 0, len(hole)+len(board))
+	cards = append(cards, hole...)
+	cards = append(cards, board...)
+	return Evaluate(cards)
+}
+
+// EvaluateShortDeck ranks the best five-card hand from hole and board cards
+// dealt from the short deck. The ace plays low in A-6-7-8-9 and a flush beats
+// a full house.
+func EvaluateShortDeck(hole, board []types.Card) (Hand, error) {
+	cards := make([]types.Card, 0, len(hole)+len(board))
+	cards = append(cards, hole...)
+	cards = append(cards, board...)
+	if len(cards) < 5 || len(cards) > 7 {
+		return Hand{}, fmt.Errorf("%w: expected 5 to 7, got %d", ErrInvalidCardCount, len(cards))
+	}
+	if err := validate(cards); err != nil {
+		return Hand{}, err
+	}
+	return evaluateRules(cards, shortDeckRules), nil
+}
+
+// EvaluateOmaha ranks the best hand that uses exactly two hole cards and
+// exactly three board cards. Four hole cards are standard; five and six
+// support PLO5 and PLO6. The board may hold 3 to 5 cards.
+func EvaluateOmaha(hole, board []types.Card) (Hand, error) {
+	if len(hole) < 4 || len(hole) > 6 {
+		return Hand{}, fmt.Errorf("%w: expected 4 to 6 hole cards, got %d", ErrInvalidCardCount, len(hole))
+	}
+	if len(board) < 3 || len(board) > 5 {
+		return Hand{}, fmt.Errorf("%w: expected 3 to 5 board cards, got %d", ErrInvalidCardCount, len(board))
+	}
+
+	all := make([]types.Card, 0, len(hole)+len(board))
+	all = append(all, hole...)
+	all = append(all, board...)
+	if err := validate(all); err != nil {
+		return Hand{}, err
+	}
+
+	var best Hand
+	five := make([]types.Card, 5)
+	for h1 := 0; h1 < len(hole); h1++ {
+		for h2 := h1 + 1; h2 < len(hole); h2++ {
+			five[0], five[1] = hole[h1], hole[h2]
+			for b1 := 0; b1 < len(board); b1++ {
+				for b2 := b1 + 1; b2 < len(board); b2++ {
+					for b3 := b2 + 1; b3 < len(board); b3++ {
+						five[2], five[3], five[4] = board[b1], board[b2], board[b3]
+						if hand := evaluate(five); hand.Score > best.Score {
+							best = hand
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, nil
+}
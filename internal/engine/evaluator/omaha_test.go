@@ -0,0 +1,151 @@
+package evaluator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// TestEvaluateOmaha tests the exactly-two-hole-cards rule
+func TestEvaluateOmaha(t *testing.T) {
+	tests := []struct {
+		name     string
+		hole     string
+		board    string
+		category HandCategory
+		used     string
+	}{
+		{"no flush with one suited hole card", "AH KC QD JS", "2H 5H 8H 9H TC", Straight, "QD JS TC 9H 8H"},
+		{"flush with two suited hole cards", "AH KH QD JS", "2H 5H 8H 9C TC", Flush, "AH KH 8H 5H 2H"},
+		{"four-card straight on board needs two hole cards", "AC AD 2S 3S", "9H TH JC QD 4S", OnePair, "AD AC QD JC TH"},
+		{"board quads play only three", "KC KD 3H 4S", "9C 9D 9H 9S AH", FullHouse, "9H 9D 9C KD KC"},
+		{"wheel with two hole cards", "AC 2D KH KS", "3C 4D 5H QS JD", Straight, "5H 4D 3C 2D AC"},
+		{"flop only", "AC AD KH KS", "AH 7C 2D", ThreeOfAKind, "AH AD AC 7C 2D"},
+		{"six-card PLO6", "AC AD KH KS QC JD", "AH QD QS 7S 9H", FullHouse, "AH AD AC QS QD"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should rank "+tt.name, func(t *testing.T) {
+			hand, err := EvaluateOmaha(parseCards(t, tt.hole), parseCards(t, tt.board))
+			if err != nil {
+				t.Fatalf("EvaluateOmaha failed: %v", err)
+			}
+
+			if hand.Category != tt.category {
+				t.Errorf("Expected %s, got %s (%s)", tt.category, hand.Category, hand.Description())
+			}
+			if used := strings.Join(hand.Mnemonics(), " "); used != tt.used {
+				t.Errorf("Expected cards %s, got %s", tt.used, used)
+			}
+		})
+	}
+
+	t.Run("should score like Hold'em for the same five cards", func(t *testing.T) {
+		omaha, _ := EvaluateOmaha(parseCards(t, "AH KH 2C 3D"), parseCards(t, "QH JH TH"))
+		holdem, _ := Evaluate(parseCards(t, "AH KH QH JH TH"))
+
+		if omaha.Score != holdem.Score || omaha.Description() != "Royal Flush" {
+			t.Errorf("Expected matching scores, got %d and %d", omaha.Score, holdem.Score)
+		}
+	})
+
+	t.Run("should reject invalid card counts and duplicates", func(t *testing.T) {
+		if _, err := EvaluateOmaha(parseCards(t, "AH KH 2C"), parseCards(t, "QH JH TH")); !errors.Is(err, ErrInvalidCardCount) {
+			t.Errorf("Expected ErrInvalidCardCount, got %v", err)
+		}
+		if _, err := EvaluateOmaha(parseCards(t, "AH KH 2C 3D"), parseCards(t, "QH JH")); !errors.Is(err, ErrInvalidCardCount) {
+			t.Errorf("Expected ErrInvalidCardCount, got %v", err)
+		}
+		if _, err := EvaluateOmaha(parseCards(t, "AH KH 2C 3D"), parseCards(t, "AH JH TH")); !errors.Is(err, ErrDuplicateCard) {
+			t.Errorf("Expected ErrDuplicateCard, got %v", err)
+		}
+	})
+}
+
+// TestForVariant tests evaluator selection by game variant
+func TestForVariant(t *testing.T) {
+	t.Run("should apply the Omaha rule for Omaha", func(t *testing.T) {
+		hole := parseCards(t, "AH 2C 3D 4S")
+		board := parseCards(t, "KH QH JH TH 9C")
+
+		holdem, _ := ForVariant(types.VariantTexasHoldem)(hole[:2], board)
+		omaha, _ := ForVariant(types.VariantOmaha)(hole, board)
+
+		if holdem.Category != StraightFlush {
+			t.Errorf("Expected Hold'em straight flush, got %s", holdem.Category)
+		}
+		if omaha.Category == StraightFlush {
+			t.Error("Expected Omaha to require two hole cards")
+		}
+	})
+
+	t.Run("should apply short deck rankings for Short Deck", func(t *testing.T) {
+		if _, err := ForVariant(types.VariantShortDeck)(parseCards(t, "AS 6D"), parseCards(t, "7H 8C 9S")); err != nil {
+			t.Fatalf("Expected short deck evaluator, got %v", err)
+		}
+	})
+}
+
+// TestEvaluateShortDeck tests the short deck rankings
+func TestEvaluateShortDeck(t *testing.T) {
+	t.Run("should count A-6-7-8-9 as a straight", func(t *testing.T) {
+		hand, err := EvaluateShortDeck(parseCards(t, "AS 6D"), parseCards(t, "7H 8C 9S KD QH"))
+		if err != nil {
+			t.Fatalf("EvaluateShortDeck failed: %v", err)
+		}
+		if hand.Category != Straight || hand.Description() != "Straight, Nine High" {
+			t.Errorf("Expected Straight, Nine High, got %s", hand.Description())
+		}
+		if got := strings.Join(hand.Mnemonics(), " "); got != "9S 8C 7H 6D AS" {
+			t.Errorf("Expected 9S 8C 7H 6D AS, got %s", got)
+		}
+
+		six, _ := EvaluateShortDeck(parseCards(t, "TS 6D"), parseCards(t, "7H 8C 9S KD QH"))
+		if six.Compare(hand) != 1 {
+			t.Error("Expected Ten High to beat Nine High")
+		}
+	})
+
+	t.Run("should rank a flush above a full house", func(t *testing.T) {
+		board := parseCards(t, "KH KD 9H 7H 6C")
+		flush, err := EvaluateShortDeck(parseCards(t, "AH TH"), board)
+		if err != nil {
+			t.Fatalf("EvaluateShortDeck failed: %v", err)
+		}
+		fullHouse, _ := EvaluateShortDeck(parseCards(t, "9S 9C"), board)
+
+		if flush.Category != Flush || fullHouse.Category != FullHouse {
+			t.Fatalf("Expected flush and full house, got %s and %s", flush.Category, fullHouse.Category)
+		}
+		if flush.Compare(fullHouse) != 1 {
+			t.Error("Expected the flush to beat the full house")
+		}
+		holdemFlush, _ := EvaluateHoldem(parseCards(t, "AH TH"), board)
+		holdemFullHouse, _ := EvaluateHoldem(parseCards(t, "9S 9C"), board)
+		if holdemFlush.Compare(holdemFullHouse) != -1 {
+			t.Error("Expected the Hold'em flush to lose to the full house")
+		}
+	})
+
+	t.Run("should pick the flush over a full house in the same cards", func(t *testing.T) {
+		hand, _ := EvaluateShortDeck(parseCards(t, "KH 9S"), parseCards(t, "KD 9H 7H 6H TH"))
+		if hand.Category != Flush {
+			t.Errorf("Expected Flush, got %s", hand.Category)
+		}
+	})
+}
+
+// BenchmarkEvaluateOmaha measures four-card Omaha evaluation on a full board
+func BenchmarkEvaluateOmaha(b *testing.B) {
+	hole := parseCards(b, "AC KD 7H 7S")
+	board := parseCards(b, "4C 4D 2H 9S TD")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EvaluateOmaha(hole, board); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
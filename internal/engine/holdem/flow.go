@@ -0,0 +1,328 @@
+package holdem
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/engine/evaluator"
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// PerformAction applies a player's action. The index must equal
+// GetActionIndex and the amount is the number of chips the action moves from
+// the player's stack, within the range given by GetLegalActions.
+func (g *TexasHoldem) PerformAction(address string, action types.PlayerActionType, index int, amount *big.Int) error {
+	player, err := g.GetPlayer(address)
+	if err != nil {
+		return err
+	}
+	a, err := actions.New(g, action)
+	if err != nil {
+		return err
+	}
+	if err := a.Execute(player, index, amount); err != nil {
+		return err
+	}
+	return g.advance()
+}
+
+// Deal deals hole cards once the blinds and antes are posted, one card at a time
+// starting left of the button. It does nothing at any other time.
+func (g *TexasHoldem) Deal() {
+	if !g.CanDeal() {
+		return
+	}
+
+	seats := g.SeatsFrom(g.GetDealerPosition(), g.InHand)
+	hands := make(map[int][]types.Card, len(seats))
+	for i := 0; i < g.holeCardCount(); i++ {
+		for _, seat := range seats {
+			cards, err := g.Deck.DealHole(seat, 1)
+			if err != nil {
+				return
+			}
+			hands[seat] = append(hands[seat], cards...)
+		}
+	}
+	for seat, cards := range hands {
+		g.Players[seat].HoleCards = cards
+	}
+
+	// The blinds may have put everyone all-in
+	_ = g.advance()
+}
+
+// advance moves the hand on through any rounds that have ended, dealing the
+// board and awarding the pot as needed
+func (g *TexasHoldem) advance() error {
+	for {
+		switch g.Round {
+		case types.RoundPreFlop, types.RoundFlop, types.RoundTurn, types.RoundRiver:
+			if g.Started() && g.CountPlayers(g.Contesting) == 1 {
+				g.AwardUncontested()
+				return nil
+			}
+			if !g.HasRoundEnded(g.Round) {
+				if acted, err := g.blindOff(); err != nil || !acted {
+					return err
+				}
+				continue
+			}
+			if g.CountPlayers(g.Contesting) == 1 {
+				g.AwardUncontested()
+				return nil
+			}
+			if g.choosingRuns() {
+				if !g.agreeRuns() {
+					return nil
+				}
+				if g.runs > 1 {
+					if err := g.runOut(); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			if err := g.nextStreet(); err != nil {
+				return err
+			}
+		case types.RoundShowdown:
+			if !g.HasRoundEnded(g.Round) {
+				if acted, err := g.blindOff(); err != nil || !acted {
+					return err
+				}
+				continue
+			}
+			if g.CountPlayers(g.Contesting) == 1 {
+				g.AwardUncontested()
+				return nil
+			}
+			return g.showdown()
+		default:
+			return nil
+		}
+	}
+}
+
+// blindOff acts for a tournament player sitting out whose turn it is. They
+// post any forced bet due, check or show when they can and otherwise fold.
+// It reports whether it acted.
+func (g *TexasHoldem) blindOff() (bool, error) {
+	if len(g.away) == 0 {
+		return false, nil
+	}
+	seat, err := g.nextToActSeat()
+	if err != nil || !g.away[g.Players[seat].Address] {
+		return false, nil
+	}
+	p := g.Players[seat]
+
+	action, amount := types.ActionFold, big.NewInt(0)
+	if post := g.GetNextPost(); post != nil {
+		action, amount = post.Action, post.Amount
+	} else {
+		legal, err := g.GetLegalActions(p.Address)
+		if err != nil {
+			return false, err
+		}
+		for _, l := range legal {
+			switch l.Action {
+			case types.ActionCheck, types.ActionShow:
+				action, amount = l.Action, l.MinAmount
+			}
+		}
+	}
+
+	a, err := actions.New(g, action)
+	if err != nil {
+		return false, err
+	}
+	if err := a.Execute(p, g.GetActionIndex(), amount); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// nextStreet burns and deals the next street and moves to its round. A
+// multi-board hand deals the street to each board in turn, burning first
+// every time.
+func (g *TexasHoldem) nextStreet() error {
+	count := 1
+	switch g.Round {
+	case types.RoundPreFlop:
+		count = 3
+	case types.RoundRiver:
+		g.Round = types.RoundShowdown
+		return nil
+	}
+
+	if g.hand.Boards > 1 {
+		if len(g.boards) == 0 {
+			g.boards = make([][]types.Card, g.hand.Boards)
+		}
+		for i := range g.boards {
+			cards, err := g.dealStreet(count)
+			if err != nil {
+				return err
+			}
+			g.boards[i] = append(g.boards[i], cards...)
+		}
+		g.board = g.boards[0]
+	} else {
+		cards, err := g.dealStreet(count)
+		if err != nil {
+			return err
+		}
+		g.board = append(g.board, cards...)
+	}
+	g.Round = rounds[g.RoundOrder(g.Round)+1]
+	return nil
+}
+
+// dealStreet burns a card and deals count cards to a board
+func (g *TexasHoldem) dealStreet(count int) ([]types.Card, error) {
+	if _, err := g.Deck.Burn(); err != nil {
+		return nil, err
+	}
+	return g.Deck.DealBoard(count)
+}
+
+// agreeRuns fixes the number of runs once every player still in has chosen,
+// taking the fewest anyone chose
+func (g *TexasHoldem) agreeRuns() bool {
+	choices := g.runChoices()
+	if len(choices) < g.CountPlayers(g.Contesting) {
+		return false
+	}
+	g.runs = g.Options.MaxRuns
+	for _, runs := range choices {
+		if runs < g.runs {
+			g.runs = runs
+		}
+	}
+	return true
+}
+
+// runOut deals the rest of the board once for each run, burning before every
+// street as usual, and moves to the showdown. Every run shares the cards
+// already dealt, and the first run's board becomes the community cards.
+func (g *TexasHoldem) runOut() error {
+	for i := 0; i < g.runs; i++ {
+		board := append([]types.Card(nil), g.board...)
+		for len(board) < 5 {
+			count := 1
+			if len(board) == 0 {
+				count = 3
+			}
+			cards, err := g.dealStreet(count)
+			if err != nil {
+				return err
+			}
+			board = append(board, cards...)
+		}
+		g.boards = append(g.boards, board)
+	}
+	g.board = g.boards[0]
+	g.Round = types.RoundShowdown
+	return nil
+}
+
+// showdown evaluates the shown hands and pays each pot, less rake, to the
+// best hands eligible for it, split high and low for hi-lo variants. A board
+// run more than once, or a multi-board hand, splits each pot evenly between
+// the boards, with odd chips going to the earlier boards. Winners are listed
+// pot by pot, starting with the main pot, and board by board within each pot.
+func (g *TexasHoldem) showdown() error {
+	boards := g.boards
+	if len(boards) == 0 {
+		boards = [][]types.Card{g.board}
+	}
+	runs := make([][]managers.Contender, len(boards))
+	for i, board := range boards {
+		contenders, err := g.contenders(board)
+		if err != nil {
+			return err
+		}
+		runs[i] = contenders
+	}
+
+	manager := g.PotManager()
+	g.ReturnUncalled(manager)
+
+	hiLo := evaluator.LowForVariant(g.Options.Variant) != nil
+	g.Winners = nil
+	for _, pot := range g.TakeRake(manager.GetPots()) {
+		for i, share := range managers.SplitAmount(pot.Amount, len(runs)) {
+			eligible := make([]managers.Contender, 0, len(pot.Eligible))
+			for _, c := range runs[i] {
+				for _, address := range pot.Eligible {
+					if c.Address == address {
+						eligible = append(eligible, c)
+					}
+				}
+			}
+			if hiLo {
+				g.Winners = append(g.Winners, managers.AwardHiLo(share, eligible)...)
+			} else {
+				g.Winners = append(g.Winners, managers.AwardHigh(share, eligible)...)
+			}
+		}
+	}
+	for _, winner := range g.Winners {
+		p, err := g.GetPlayer(winner.Name)
+		if err != nil {
+			return err
+		}
+		p.Chips = new(big.Int).Add(p.Chips, winner.Amount)
+	}
+
+	g.Round = types.RoundEnd
+	return nil
+}
+
+// contenders evaluates the hand of each player still in against a board,
+// ordered from the first seat left of the button so odd chips go to them
+func (g *TexasHoldem) contenders(board []types.Card) ([]managers.Contender, error) {
+	evaluate := evaluator.ForVariant(g.Options.Variant)
+	evaluateLow := evaluator.LowForVariant(g.Options.Variant)
+
+	var contenders []managers.Contender
+	for _, seat := range g.SeatsFrom(g.GetDealerPosition(), g.Contesting) {
+		p := g.Players[seat]
+		high, err := evaluate(p.HoleCards, board)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating seat %d: %w", seat, err)
+		}
+		contender := managers.Contender{Address: p.Address, High: high}
+		if evaluateLow != nil {
+			low, ok, err := evaluateLow(p.HoleCards, board)
+			if err != nil {
+				return nil, fmt.Errorf("evaluating seat %d: %w", seat, err)
+			}
+			if ok {
+				contender.Low = &low
+			}
+		}
+		contenders = append(contenders, contender)
+	}
+	return contenders, nil
+}
+
+// ReInit starts the next hand with a fresh deck once the current hand is
+// over. The big blind moves to the next player who can be dealt in and the
+// button follows under the dead button rule.
+func (g *TexasHoldem) ReInit(deck string) error {
+	if err := g.NextHand(deck); err != nil {
+		return err
+	}
+	g.board = nil
+	g.runs = 0
+	g.boards = nil
+	g.hand = g.nextHand
+	g.nextHand = types.HandOptions{}
+
+	// Players sitting out of a tournament post their forced bets at once
+	return g.advance()
+}
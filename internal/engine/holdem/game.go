@@ -0,0 +1,437 @@
+package holdem
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/engine/base"
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by the game engine
+var (
+	ErrInvalidOptions = base.ErrInvalidOptions
+	ErrInvalidSeat    = base.ErrInvalidSeat
+	ErrSeatTaken      = base.ErrSeatTaken
+	ErrAlreadySeated  = base.ErrAlreadySeated
+	ErrPlayerNotFound = base.ErrPlayerNotFound
+	ErrNotYourTurn    = actions.ErrNotYourTurn
+	ErrInvalidIndex   = actions.ErrInvalidIndex
+	ErrIllegalAction  = actions.ErrIllegalAction
+	ErrInvalidAmount  = actions.ErrInvalidAmount
+	ErrHandInProgress = base.ErrHandInProgress
+	ErrSeatingClosed  = base.ErrSeatingClosed
+	ErrNoPlayerToAct  = base.ErrNoPlayerToAct
+	ErrNoActions      = base.ErrNoActions
+)
+
+var (
+	_ types.IPoker  = (*TexasHoldem)(nil)
+	_ types.IDealer = (*TexasHoldem)(nil)
+)
+
+// rounds lists the rounds of a hand in the order they are played
+var rounds = []types.TexasHoldemRound{
+	types.RoundPreFlop,
+	types.RoundFlop,
+	types.RoundTurn,
+	types.RoundRiver,
+	types.RoundShowdown,
+	types.RoundEnd,
+}
+
+// TexasHoldem is a community-card poker table implementing types.IPoker.
+// Omaha variants are played by the same engine with more hole cards.
+//
+// A hand starts in the pre-flop round with the blinds posted as turns, then
+// Deal hands out hole cards and betting begins. Streets are dealt as each
+// betting round ends, and the remaining players show or muck before the pot
+// is awarded and the round moves to END. ReInit starts the next hand.
+type TexasHoldem struct {
+	*base.Game
+	away     map[string]bool // Tournament players sitting out, still dealt in and blinded off
+	blinds   *managers.BlindsManager
+	board    []types.Card
+	runs     int            // Times the players agreed to run the board, 0 until they choose
+	boards   [][]types.Card // Every board of a multi-board hand or one run more than once
+	hand     types.HandOptions
+	nextHand types.HandOptions // Options for the hand ReInit starts
+}
+
+// NewTexasHoldem creates an empty table that will deal its first hand from deck
+func NewTexasHoldem(options types.GameOptions, deck string) (*TexasHoldem, error) {
+	switch options.Variant {
+	case types.VariantSevenCardStud:
+		return nil, fmt.Errorf("%w: %s is dealt by the stud engine", ErrInvalidOptions, options.Variant)
+	case types.VariantFiveCardDraw, types.VariantTripleDraw:
+		return nil, fmt.Errorf("%w: %s is dealt by the draw engine", ErrInvalidOptions, options.Variant)
+	}
+	if err := base.CheckOptions(&options); err != nil {
+		return nil, err
+	}
+
+	g := &TexasHoldem{
+		away:   make(map[string]bool),
+		blinds: managers.NewBlindsManager(options),
+	}
+	game, err := base.New(g, options, deck, rounds)
+	if err != nil {
+		return nil, err
+	}
+	g.Game = game
+	g.Positions = managers.NewDealerPositionManager(g)
+	return g, nil
+}
+
+// SetBlinds changes the blinds and ante between hands as tournament levels
+// rise. Fixed-limit bets follow the big blind, the small bet equal to it and
+// the big bet twice that. It fails while a hand is being played.
+func (g *TexasHoldem) SetBlinds(smallBlind, bigBlind, ante *big.Int) error {
+	if g.Started() && g.Round != types.RoundEnd {
+		return fmt.Errorf("%w: %s", ErrHandInProgress, g.Round)
+	}
+	if smallBlind == nil || bigBlind == nil || smallBlind.Sign() <= 0 || bigBlind.Cmp(smallBlind) < 0 {
+		return fmt.Errorf("%w: blinds must be positive with big blind >= small blind", ErrInvalidOptions)
+	}
+	if ante != nil && ante.Sign() < 0 {
+		return fmt.Errorf("%w: negative ante %s", ErrInvalidOptions, ante)
+	}
+
+	g.Options.SmallBlind = new(big.Int).Set(smallBlind)
+	g.Options.BigBlind = new(big.Int).Set(bigBlind)
+	g.Options.Ante = nil
+	if ante != nil {
+		g.Options.Ante = new(big.Int).Set(ante)
+	}
+	if g.Options.Betting == types.BettingFixedLimit {
+		g.Options.SmallBet = new(big.Int).Set(bigBlind)
+		g.Options.BigBet = new(big.Int).Mul(bigBlind, big.NewInt(2))
+	}
+	g.blinds = managers.NewBlindsManager(g.Options)
+	return nil
+}
+
+// GetFixedBet returns the size of a fixed-limit bet or raise in the current
+// round: the small bet pre-flop and on the flop, the big bet after. It is nil
+// at tables without fixed-limit betting.
+func (g *TexasHoldem) GetFixedBet() *big.Int {
+	if g.Options.Betting != types.BettingFixedLimit {
+		return nil
+	}
+	switch g.Round {
+	case types.RoundPreFlop, types.RoundFlop:
+		return new(big.Int).Set(g.Options.SmallBet)
+	default:
+		return new(big.Int).Set(g.Options.BigBet)
+	}
+}
+
+// GetCommunityCards returns the board dealt so far
+func (g *TexasHoldem) GetCommunityCards() []types.Card {
+	return append([]types.Card(nil), g.board...)
+}
+
+// GetBoards returns each board of the current hand, one per run or per board
+// of a multi-board hand, with the first as the community cards. Otherwise
+// there is a single board.
+func (g *TexasHoldem) GetBoards() [][]types.Card {
+	if len(g.boards) == 0 {
+		return [][]types.Card{g.GetCommunityCards()}
+	}
+	boards := make([][]types.Card, len(g.boards))
+	for i, board := range g.boards {
+		boards[i] = append([]types.Card(nil), board...)
+	}
+	return boards
+}
+
+// GetHandOptions returns the options of the current hand
+func (g *TexasHoldem) GetHandOptions() types.HandOptions {
+	options := g.hand
+	if options.BombPot != nil {
+		options.BombPot = new(big.Int).Set(options.BombPot)
+	}
+	return options
+}
+
+// SetHandOptions makes a hand a bomb pot, deals it more than one board, or
+// both. Before the current hand starts the options apply to it; once it is
+// over they apply to the hand ReInit starts. Only cash games deal them.
+func (g *TexasHoldem) SetHandOptions(options types.HandOptions) error {
+	if options.BombPot != nil && options.BombPot.Sign() == 0 {
+		options.BombPot = nil
+	}
+	if options.Boards == 1 {
+		options.Boards = 0
+	}
+	if !options.IsStandard() && g.Options.Format != types.FormatCash {
+		return fmt.Errorf("%w: bomb pots and extra boards are only dealt in cash games", ErrInvalidOptions)
+	}
+	if options.BombPot != nil && options.BombPot.Sign() < 0 {
+		return fmt.Errorf("%w: negative bomb pot %s", ErrInvalidOptions, options.BombPot)
+	}
+	// Every board burns a card before each of its three streets
+	if options.Boards < 0 || g.Options.MaxPlayers*g.holeCardCount()+8*options.Boards > 52 {
+		return fmt.Errorf("%w: %d boards cannot be dealt to %d players", ErrInvalidOptions, options.Boards, g.Options.MaxPlayers)
+	}
+
+	switch {
+	case !g.Started():
+		g.hand = options
+	case g.Round == types.RoundEnd:
+		g.nextHand = options
+	default:
+		return fmt.Errorf("%w: %s", ErrHandInProgress, g.Round)
+	}
+	return nil
+}
+
+// GetDealerPosition returns the button seat. Under the dead button rule it
+// may be empty. Before the first hand starts the button is assumed to be on
+// the first player dealt in.
+func (g *TexasHoldem) GetDealerPosition() int {
+	return g.Positions.GetDealerPosition()
+}
+
+// GetSmallBlindPosition returns the small blind seat. Heads-up the button
+// posts the small blind. A dead small blind's seat has nobody to post it.
+func (g *TexasHoldem) GetSmallBlindPosition() int {
+	return g.Positions.GetSmallBlindPosition()
+}
+
+// GetBigBlindPosition returns the big blind seat
+func (g *TexasHoldem) GetBigBlindPosition() int {
+	return g.Positions.GetBigBlindPosition()
+}
+
+// HasDeadSmallBlind reports whether nobody posts the small blind this hand
+// because its player left
+func (g *TexasHoldem) HasDeadSmallBlind() bool {
+	p, ok := g.Players[g.GetSmallBlindPosition()]
+	return !ok || !g.InHand(p)
+}
+
+// holeCardCount returns how many hole cards the variant deals
+func (g *TexasHoldem) holeCardCount() int {
+	switch g.Options.Variant {
+	case types.VariantOmaha, types.VariantOmahaHiLo:
+		return 4
+	default:
+		return 2
+	}
+}
+
+// GetNextPost returns the blind or ante due next this hand, or nil once every
+// forced bet is in or the hand cannot start. In a bomb pot every player antes
+// and nobody posts a blind.
+func (g *TexasHoldem) GetNextPost() *managers.Post {
+	if g.Round != types.RoundPreFlop || g.Dealt() || g.CountPlayers(g.InHand) < g.Options.MinPlayers {
+		return nil
+	}
+
+	var players []types.IPlayer
+	for _, seat := range g.SeatsFrom(g.GetDealerPosition(), g.InHand) {
+		players = append(players, g.Players[seat])
+	}
+	if g.hand.BombPot != nil {
+		return g.blinds.GetBombPotPost(players, g.hand.BombPot, g.GetTurns(types.RoundPreFlop))
+	}
+	return g.blinds.GetNextPost(players, g.AddressAt(g.GetDealerPosition()),
+		g.AddressAt(g.GetSmallBlindPosition()), g.AddressAt(g.GetBigBlindPosition()), g.GetTurns(types.RoundPreFlop))
+}
+
+// GetNextPlayerToAct returns the player whose turn it is
+func (g *TexasHoldem) GetNextPlayerToAct() (types.IPlayer, error) {
+	seat, err := g.nextToActSeat()
+	if err != nil {
+		return nil, err
+	}
+	return g.Players[seat], nil
+}
+
+// nextToActSeat finds the seat of the player whose turn it is
+func (g *TexasHoldem) nextToActSeat() (int, error) {
+	switch g.Round {
+	case types.RoundPreFlop:
+		if g.CountPlayers(g.InHand) < g.Options.MinPlayers {
+			return 0, fmt.Errorf("%w: waiting for %d players", ErrNoPlayerToAct, g.Options.MinPlayers)
+		}
+		if post := g.GetNextPost(); post != nil {
+			return g.GetPlayerSeatNumber(post.Address), nil
+		}
+		if !g.Dealt() {
+			return 0, fmt.Errorf("%w: waiting for the deal", ErrNoPlayerToAct)
+		}
+		return g.nextBettor()
+	case types.RoundFlop, types.RoundTurn, types.RoundRiver:
+		return g.nextBettor()
+	case types.RoundShowdown:
+		seat := g.NextSeat(g.GetDealerPosition(), func(p *models.Player) bool {
+			return g.Contesting(p) && !g.HasShown(p.Address)
+		})
+		if seat == 0 {
+			return 0, fmt.Errorf("%w: showdown complete", ErrNoPlayerToAct)
+		}
+		return seat, nil
+	default:
+		return 0, fmt.Errorf("%w: hand is over", ErrNoPlayerToAct)
+	}
+}
+
+// nextBettor finds the next player who must act in the current betting round,
+// starting after the last player to act or, with no action yet, after the button
+func (g *TexasHoldem) nextBettor() (int, error) {
+	// Once nobody is left to bet, the players still in choose how many
+	// times to run the board starting left of the button
+	if g.choosingRuns() {
+		choices := g.runChoices()
+		seat := g.NextSeat(g.GetDealerPosition(), func(p *models.Player) bool {
+			_, chosen := choices[p.Address]
+			return g.Contesting(p) && !chosen
+		})
+		if seat != 0 {
+			return seat, nil
+		}
+	}
+	if g.HasRoundEnded(g.Round) {
+		return 0, fmt.Errorf("%w: %s betting is complete", ErrNoPlayerToAct, g.Round)
+	}
+
+	// Pre-flop the action starts left of the big blind, or of a straddle,
+	// whatever order the blinds and antes were posted in
+	from := g.GetDealerPosition()
+	if g.Round == types.RoundPreFlop {
+		from = g.GetBigBlindPosition()
+	}
+	turns := g.Turns[g.Round]
+	for i := len(turns) - 1; i >= 0; i-- {
+		switch turns[i].Action {
+		case types.ActionSmallBlind, types.ActionBigBlind, types.ActionAnte:
+			continue
+		}
+		from = turns[i].Seat
+		break
+	}
+
+	bets := g.BetManager(g.Round)
+	largest := bets.GetLargestBet()
+	seat := g.NextSeat(from, func(p *models.Player) bool {
+		return g.CanAct(p) && (!bets.HasActed(p.Address) || bets.GetBet(p.Address).Cmp(largest) < 0)
+	})
+	if seat == 0 {
+		return 0, fmt.Errorf("%w: %s betting is complete", ErrNoPlayerToAct, g.Round)
+	}
+	return seat, nil
+}
+
+// HasRoundEnded reports whether a round of the current hand is complete.
+// Earlier rounds have ended and later rounds have not.
+func (g *TexasHoldem) HasRoundEnded(round types.TexasHoldemRound) bool {
+	if order, current := g.RoundOrder(round), g.RoundOrder(g.Round); order != current {
+		return order < current
+	}
+
+	switch round {
+	case types.RoundPreFlop, types.RoundFlop, types.RoundTurn, types.RoundRiver:
+		if round == types.RoundPreFlop && !g.Dealt() {
+			return false
+		}
+		// Bomb pots go straight to the flop
+		if round == types.RoundPreFlop && g.hand.BombPot != nil {
+			return true
+		}
+		return g.BettingComplete(round)
+	case types.RoundShowdown:
+		return g.CountPlayers(func(p *models.Player) bool {
+			return g.Contesting(p) && !g.HasShown(p.Address)
+		}) == 0 || g.CountPlayers(g.Contesting) <= 1
+	default:
+		return true
+	}
+}
+
+// GetMaxRuns returns the most times the board may be run while the players
+// choose, or 0 when they are not choosing
+func (g *TexasHoldem) GetMaxRuns() int {
+	if !g.choosingRuns() {
+		return 0
+	}
+	return g.maxRuns()
+}
+
+// choosingRuns reports whether the players are choosing how many times to
+// run the board: betting is over before the river with two or more players
+// in the hand and at most one of them able to bet
+func (g *TexasHoldem) choosingRuns() bool {
+	switch g.Round {
+	case types.RoundPreFlop, types.RoundFlop, types.RoundTurn:
+	default:
+		return false
+	}
+	if g.runs > 0 || g.hand.Boards > 1 || g.maxRuns() < 2 || !g.HasRoundEnded(g.Round) {
+		return false
+	}
+	return g.CountPlayers(g.Contesting) >= 2 && g.CountPlayers(g.CanAct) <= 1
+}
+
+// maxRuns returns the most times the rest of the board can be run, limited
+// by the table options and the cards left in the deck
+func (g *TexasHoldem) maxRuns() int {
+	// Each run burns a card before every street it deals
+	var needed int
+	switch len(g.board) {
+	case 0:
+		needed = 8
+	case 3:
+		needed = 4
+	default:
+		needed = 2
+	}
+	if runs := g.Deck.Remaining() / needed; runs < g.Options.MaxRuns {
+		return runs
+	}
+	return g.Options.MaxRuns
+}
+
+// runChoices returns the number of runs each player has chosen this round
+func (g *TexasHoldem) runChoices() map[string]int {
+	choices := make(map[string]int)
+	for _, turn := range g.Turns[g.Round] {
+		if turn.Action == types.ActionRunIt {
+			choices[turn.PlayerID] = int(turn.Amount.Int64())
+		}
+	}
+	return choices
+}
+
+// GetLegalActions returns the actions the player may take now, with the
+// chips each would move
+func (g *TexasHoldem) GetLegalActions(address string) ([]types.LegalActionDTO, error) {
+	player, err := g.GetPlayer(address)
+	if err != nil {
+		return nil, err
+	}
+
+	seat, err := g.nextToActSeat()
+	if err != nil {
+		return nil, err
+	}
+	if seat != player.Seat {
+		return nil, fmt.Errorf("%w: %s in seat %d, expected seat %d", ErrNotYourTurn, address, player.Seat, seat)
+	}
+
+	var legal []types.LegalActionDTO
+	for _, action := range actions.PlayerActions {
+		a, err := actions.New(g, action)
+		if err != nil {
+			return nil, err
+		}
+		if r, err := a.Verify(player); err == nil {
+			legal = append(legal, types.LegalActionDTO{Action: action, MinAmount: r.MinAmount, MaxAmount: r.MaxAmount})
+		}
+	}
+	return legal, nil
+}
@@ -0,0 +1,900 @@
+package holdem
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// stackedDeck returns a deck string with the given mnemonics on top, followed
+// by the rest of a standard deck in order
+func stackedDeck(t testing.TB, top string) string {
+	t.Helper()
+	return stackedDeckOf(t, models.StandardComposition, top)
+}
+
+// stackedDeckOf returns a deck string with the given mnemonics on top,
+// followed by the rest of the composition in order
+func stackedDeckOf(t testing.TB, composition models.DeckComposition, top string) string {
+	t.Helper()
+
+	first := strings.Fields(top)
+	used := make(map[string]bool, len(first))
+	for _, mnemonic := range first {
+		used[mnemonic] = true
+	}
+
+	standard, err := models.NewDeckWithComposition("", composition)
+	if err != nil {
+		t.Fatalf("NewDeckWithComposition failed: %v", err)
+	}
+	cards := append([]string(nil), first...)
+	for _, mnemonic := range strings.Split(strings.Trim(standard.ToString(), "[]"), "-") {
+		mnemonic = strings.Trim(mnemonic, "[]")
+		if !used[mnemonic] {
+			cards = append(cards, mnemonic)
+		}
+	}
+	cards[0] = "[" + cards[0] + "]"
+	return strings.Join(cards, "-")
+}
+
+// testOptions returns the options of a no-limit cash table with blinds 1/2
+func testOptions() types.GameOptions {
+	return types.GameOptions{
+		Format:     types.FormatCash,
+		Variant:    types.VariantTexasHoldem,
+		SmallBlind: big.NewInt(1),
+		BigBlind:   big.NewInt(2),
+		MinPlayers: 2,
+		MaxPlayers: 9,
+	}
+}
+
+// newTestGame creates a table with testOptions and seats players with the
+// given stacks in seats 1, 2, 3...
+func newTestGame(t *testing.T, deck string, stacks ...int64) *TexasHoldem {
+	t.Helper()
+	return newTestGameWith(t, testOptions(), deck, stacks...)
+}
+
+// newTestGameWith creates a table with the given options and seats players
+// with the given stacks in seats 1, 2, 3...
+func newTestGameWith(t *testing.T, options types.GameOptions, deck string, stacks ...int64) *TexasHoldem {
+	t.Helper()
+
+	game, err := NewTexasHoldem(options, deck)
+	if err != nil {
+		t.Fatalf("NewTexasHoldem failed: %v", err)
+	}
+
+	for i, stack := range stacks {
+		player := models.NewPlayer(testAddress(i+1), big.NewInt(stack), i+1)
+		if err := game.AddPlayer(player); err != nil {
+			t.Fatalf("AddPlayer failed: %v", err)
+		}
+	}
+	return game
+}
+
+// testAddress names the player seated at a seat in newTestGame
+func testAddress(seat int) string {
+	return []string{"", "alice", "bob", "carol", "dave", "erin", "frank", "grace", "heidi", "ivan"}[seat]
+}
+
+// act performs an action with the next index, failing the test on error
+func act(t *testing.T, game *TexasHoldem, address string, action types.PlayerActionType, amount int64) {
+	t.Helper()
+
+	if err := game.PerformAction(address, action, game.GetActionIndex(), big.NewInt(amount)); err != nil {
+		t.Fatalf("%s %s %d failed: %v", address, action, amount, err)
+	}
+}
+
+// postForced posts the blinds and any antes due, leaving a straddle unposted
+func postForced(t *testing.T, game *TexasHoldem) {
+	t.Helper()
+
+	for post := game.GetNextPost(); post != nil && !post.Optional; post = game.GetNextPost() {
+		act(t, game, post.Address, post.Action, post.Amount.Int64())
+	}
+}
+
+// postBlinds posts the blinds and any antes due, then deals
+func postBlinds(t *testing.T, game *TexasHoldem) {
+	t.Helper()
+
+	postForced(t, game)
+	game.Deal()
+}
+
+// expectNext fails the test unless address is next to act
+func expectNext(t *testing.T, game *TexasHoldem, address string) {
+	t.Helper()
+
+	next, err := game.GetNextPlayerToAct()
+	if err != nil {
+		t.Fatalf("GetNextPlayerToAct failed: %v", err)
+	}
+	if next.GetAddress() != address {
+		t.Fatalf("Expected %s to act, got %s", address, next.GetAddress())
+	}
+}
+
+// chips returns a player's stack
+func chips(t *testing.T, game *TexasHoldem, address string) int64 {
+	t.Helper()
+
+	p, err := game.GetPlayer(address)
+	if err != nil {
+		t.Fatalf("GetPlayer failed: %v", err)
+	}
+	return p.Chips.Int64()
+}
+
+// TestTexasHoldem_HeadsUp plays a heads-up hand from blinds to showdown
+func TestTexasHoldem_HeadsUp(t *testing.T) {
+	// Dealt from seat 2: bob KH, alice AH, bob KS, alice AS, then the board
+	deck := stackedDeck(t, "KH AH KS AS 3C 7D 8C 2H 4C 9S 5C JD")
+	game := newTestGame(t, deck, 100, 100)
+
+	t.Run("should post the small blind on the button", func(t *testing.T) {
+		if game.GetDealerPosition() != 1 || game.GetSmallBlindPosition() != 1 || game.GetBigBlindPosition() != 2 {
+			t.Errorf("Expected button and small blind in seat 1, big blind in seat 2")
+		}
+		expectNext(t, game, "alice")
+	})
+
+	t.Run("should deal hole cards after the blinds", func(t *testing.T) {
+		postBlinds(t, game)
+
+		alice, _ := game.GetPlayer("alice")
+		if len(alice.HoleCards) != 2 || alice.HoleCards[0].Mnemonic != "AH" || alice.HoleCards[1].Mnemonic != "AS" {
+			t.Errorf("Expected alice to hold AH AS, got %v", alice.HoleCards)
+		}
+		if game.GetPot().Int64() != 3 {
+			t.Errorf("Expected pot of 3, got %s", game.GetPot())
+		}
+	})
+
+	t.Run("should let the button act first pre-flop", func(t *testing.T) {
+		expectNext(t, game, "alice")
+
+		legal, err := game.GetLegalActions("alice")
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		want := map[types.PlayerActionType][2]int64{
+			types.ActionFold:  {0, 0},
+			types.ActionCall:  {1, 1},
+			types.ActionRaise: {3, 99},
+			types.ActionAllIn: {99, 99},
+		}
+		if len(legal) != len(want) {
+			t.Fatalf("Expected %d legal actions, got %+v", len(want), legal)
+		}
+		for _, l := range legal {
+			r, ok := want[l.Action]
+			if !ok || l.MinAmount.Int64() != r[0] || l.MaxAmount.Int64() != r[1] {
+				t.Errorf("Unexpected legal action %s [%s, %s]", l.Action, l.MinAmount, l.MaxAmount)
+			}
+		}
+	})
+
+	t.Run("should give the big blind an option", func(t *testing.T) {
+		act(t, game, "alice", types.ActionCall, 1)
+		expectNext(t, game, "bob")
+		act(t, game, "bob", types.ActionCheck, 0)
+
+		if game.GetCurrentRound() != types.RoundFlop {
+			t.Fatalf("Expected flop, got %s", game.GetCurrentRound())
+		}
+		if board := game.GetCommunityCards(); len(board) != 3 || board[0].Mnemonic != "7D" {
+			t.Errorf("Expected burn then 7D 8C 2H, got %v", board)
+		}
+	})
+
+	t.Run("should act first out of position after the flop", func(t *testing.T) {
+		for _, round := range []types.TexasHoldemRound{types.RoundFlop, types.RoundTurn, types.RoundRiver} {
+			if game.GetCurrentRound() != round {
+				t.Fatalf("Expected %s, got %s", round, game.GetCurrentRound())
+			}
+			expectNext(t, game, "bob")
+			act(t, game, "bob", types.ActionCheck, 0)
+			act(t, game, "alice", types.ActionCheck, 0)
+		}
+
+		if board := game.GetCommunityCards(); len(board) != 5 || board[3].Mnemonic != "9S" || board[4].Mnemonic != "JD" {
+			t.Errorf("Unexpected board %v", board)
+		}
+	})
+
+	t.Run("should award the pot at showdown", func(t *testing.T) {
+		if game.GetCurrentRound() != types.RoundShowdown {
+			t.Fatalf("Expected showdown, got %s", game.GetCurrentRound())
+		}
+		expectNext(t, game, "bob")
+		act(t, game, "bob", types.ActionShow, 0)
+		act(t, game, "alice", types.ActionShow, 0)
+
+		if game.GetCurrentRound() != types.RoundEnd {
+			t.Fatalf("Expected end of hand, got %s", game.GetCurrentRound())
+		}
+		winners := game.GetWinners()
+		if len(winners) != 1 || winners[0].Name != "alice" || winners[0].Amount.Int64() != 4 {
+			t.Fatalf("Expected alice to win 4, got %+v", winners)
+		}
+		if winners[0].Description != "Pair of Aces" {
+			t.Errorf("Expected Pair of Aces, got %q", winners[0].Description)
+		}
+		if chips(t, game, "alice") != 102 || chips(t, game, "bob") != 98 {
+			t.Errorf("Expected stacks 102/98, got %d/%d", chips(t, game, "alice"), chips(t, game, "bob"))
+		}
+	})
+
+	t.Run("should move the button for the next hand", func(t *testing.T) {
+		if err := game.ReInit(stackedDeck(t, "")); err != nil {
+			t.Fatalf("ReInit failed: %v", err)
+		}
+		if game.GetDealerPosition() != 2 || game.GetSmallBlindPosition() != 2 || game.GetBigBlindPosition() != 1 {
+			t.Errorf("Expected button and small blind in seat 2")
+		}
+		if game.GetHandNumber() != 2 || game.GetPot().Sign() != 0 || game.GetCurrentRound() != types.RoundPreFlop {
+			t.Errorf("Expected a fresh hand")
+		}
+	})
+}
+
+// TestTexasHoldem_ThreeHanded tests positions, folding and all-in run-outs
+func TestTexasHoldem_ThreeHanded(t *testing.T) {
+	t.Run("should start action left of the big blind", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100, 100, 100)
+		postBlinds(t, game)
+
+		if game.GetSmallBlindPosition() != 2 || game.GetBigBlindPosition() != 3 {
+			t.Fatalf("Expected blinds in seats 2 and 3")
+		}
+		expectNext(t, game, "alice")
+	})
+
+	t.Run("should award the pot when everyone else folds", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100, 100, 100)
+		postBlinds(t, game)
+
+		act(t, game, "alice", types.ActionRaise, 6)
+		act(t, game, "bob", types.ActionFold, 0)
+		act(t, game, "carol", types.ActionFold, 0)
+
+		if game.GetCurrentRound() != types.RoundEnd {
+			t.Fatalf("Expected end of hand, got %s", game.GetCurrentRound())
+		}
+		if chips(t, game, "alice") != 103 || chips(t, game, "bob") != 99 || chips(t, game, "carol") != 98 {
+			t.Errorf("Unexpected stacks %d/%d/%d", chips(t, game, "alice"), chips(t, game, "bob"), chips(t, game, "carol"))
+		}
+		if _, err := game.GetNextPlayerToAct(); !errors.Is(err, ErrNoPlayerToAct) {
+			t.Errorf("Expected ErrNoPlayerToAct, got %v", err)
+		}
+	})
+
+	t.Run("should run out the board when everyone is all-in", func(t *testing.T) {
+		// Dealt from seat 2: bob, carol, alice twice around
+		deck := stackedDeck(t, "2C 7D AS 3H 8S AD 4C KC 7H 9H 5S QC JH 6D")
+		game := newTestGame(t, deck, 50, 50, 50)
+		postBlinds(t, game)
+
+		act(t, game, "alice", types.ActionAllIn, 50)
+		act(t, game, "bob", types.ActionAllIn, 49)
+		act(t, game, "carol", types.ActionAllIn, 48)
+
+		if game.GetCurrentRound() != types.RoundShowdown || len(game.GetCommunityCards()) != 5 {
+			t.Fatalf("Expected the board to run out, got %s with %v", game.GetCurrentRound(), game.GetCommunityCards())
+		}
+		for _, address := range []string{"bob", "carol", "alice"} {
+			act(t, game, address, types.ActionShow, 0)
+		}
+
+		if chips(t, game, "alice") != 150 {
+			t.Errorf("Expected alice to win 150 with aces, got %d", chips(t, game, "alice"))
+		}
+		if game.GetWinners()[0].Description != "Pair of Aces" {
+			t.Errorf("Unexpected winning hand %q", game.GetWinners()[0].Description)
+		}
+	})
+}
+
+// TestTexasHoldem_SidePots tests pots for all-in players with different stacks
+func TestTexasHoldem_SidePots(t *testing.T) {
+	t.Run("should pay the main and side pots to different players", func(t *testing.T) {
+		// Dealt from seat 2: bob, carol, alice twice around, then burn and flop
+		deck := stackedDeck(t, "KS QS AS KD QD AD 5C 2C 7D 9H 8C 3S 8D JD")
+		game := newTestGame(t, deck, 20, 50, 100)
+		postBlinds(t, game)
+
+		act(t, game, "alice", types.ActionAllIn, 20)
+		act(t, game, "bob", types.ActionAllIn, 49)
+		act(t, game, "carol", types.ActionCall, 48)
+
+		pots := game.GetPots()
+		if len(pots) != 2 || pots[0].Amount.Int64() != 60 || len(pots[0].Eligible) != 3 ||
+			pots[1].Amount.Int64() != 60 || len(pots[1].Eligible) != 2 || pots[1].Eligible[0] != "bob" {
+			t.Fatalf("Expected a main pot of 60 and a side pot of 60 for bob and carol, got %+v", pots)
+		}
+
+		for _, address := range []string{"bob", "carol", "alice"} {
+			act(t, game, address, types.ActionShow, 0)
+		}
+		winners := game.GetWinners()
+		if len(winners) != 2 || winners[0].Name != "alice" || winners[1].Name != "bob" {
+			t.Fatalf("Expected alice to win the main pot and bob the side pot, got %+v", winners)
+		}
+		if chips(t, game, "alice") != 60 || chips(t, game, "bob") != 60 || chips(t, game, "carol") != 50 {
+			t.Errorf("Unexpected stacks %d/%d/%d", chips(t, game, "alice"), chips(t, game, "bob"), chips(t, game, "carol"))
+		}
+	})
+
+	t.Run("should return an uncalled all-in", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100, 30)
+		postBlinds(t, game)
+
+		act(t, game, "alice", types.ActionAllIn, 99)
+		act(t, game, "bob", types.ActionAllIn, 28)
+
+		pots := game.GetPots()
+		if len(pots) != 1 || pots[0].Amount.Int64() != 60 {
+			t.Fatalf("Expected one pot of 60, got %+v", pots)
+		}
+		act(t, game, "bob", types.ActionShow, 0)
+		act(t, game, "alice", types.ActionShow, 0)
+
+		if total := chips(t, game, "alice") + chips(t, game, "bob"); total != 130 || chips(t, game, "alice") < 70 {
+			t.Errorf("Expected alice to get 70 back, got stacks %d/%d", chips(t, game, "alice"), chips(t, game, "bob"))
+		}
+	})
+}
+
+// TestTexasHoldem_Rake tests taking and recording the rake
+func TestTexasHoldem_Rake(t *testing.T) {
+	options := testOptions()
+	options.Rake = &types.RakeOptions{BasisPoints: 500, Cap: big.NewInt(3), NoFlopNoDrop: true}
+
+	t.Run("should record no rake when the hand ends before the flop", func(t *testing.T) {
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100)
+		postBlinds(t, game)
+		act(t, game, "alice", types.ActionFold, 0)
+
+		records := game.GetRakeRecords()
+		if len(records) != 1 || records[0].HandNumber != 1 || records[0].Total.Sign() != 0 {
+			t.Fatalf("Expected a zero rake record for hand 1, got %+v", records)
+		}
+		if chips(t, game, "bob") != 101 {
+			t.Errorf("Expected bob to win the blinds unraked, got %d", chips(t, game, "bob"))
+		}
+	})
+
+	t.Run("should rake the pot after the flop", func(t *testing.T) {
+		// Dealt from seat 2: bob KH, alice AH, bob KS, alice AS, then the board
+		deck := stackedDeck(t, "KH AH KS AS 3C 7D 8C 2H 4C 9S 5C JD")
+		game := newTestGameWith(t, options, deck, 100, 100)
+		postBlinds(t, game)
+		act(t, game, "alice", types.ActionCall, 1)
+		act(t, game, "bob", types.ActionCheck, 0)
+		act(t, game, "bob", types.ActionBet, 20)
+		act(t, game, "alice", types.ActionCall, 20)
+		for _, round := range []types.TexasHoldemRound{types.RoundTurn, types.RoundRiver} {
+			if game.GetCurrentRound() != round {
+				t.Fatalf("Expected %s, got %s", round, game.GetCurrentRound())
+			}
+			act(t, game, "bob", types.ActionCheck, 0)
+			act(t, game, "alice", types.ActionCheck, 0)
+		}
+		act(t, game, "bob", types.ActionShow, 0)
+		act(t, game, "alice", types.ActionShow, 0)
+
+		records := game.GetRakeRecords()
+		if len(records) != 1 || records[0].Total.Int64() != 2 || records[0].Players != 2 {
+			t.Fatalf("Expected 2 raked from a two-player hand, got %+v", records)
+		}
+		if winners := game.GetWinners(); winners[0].Amount.Int64() != 42 || chips(t, game, "alice") != 120 {
+			t.Errorf("Expected alice to win 42, got %s and a stack of %d", winners[0].Amount, chips(t, game, "alice"))
+		}
+	})
+
+	t.Run("should reject a rake above 100%", func(t *testing.T) {
+		options := testOptions()
+		options.Rake = &types.RakeOptions{BasisPoints: 10001}
+		if _, err := NewTexasHoldem(options, ""); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions, got %v", err)
+		}
+	})
+
+	t.Run("should convert the deprecated percentage to basis points", func(t *testing.T) {
+		options := testOptions()
+		options.RakePercentage = 4.5
+		game, err := NewTexasHoldem(options, "")
+		if err != nil {
+			t.Fatalf("NewTexasHoldem failed: %v", err)
+		}
+		if game.Options.Rake == nil || game.Options.Rake.BasisPoints != 450 {
+			t.Errorf("Expected 450 basis points, got %+v", game.Options.Rake)
+		}
+	})
+}
+
+// TestTexasHoldem_Antes tests posting antes before the deal
+func TestTexasHoldem_Antes(t *testing.T) {
+	t.Run("should collect classic antes after the blinds and keep them out of the bets", func(t *testing.T) {
+		options := testOptions()
+		options.Ante = big.NewInt(1)
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 1, 100, 100)
+
+		act(t, game, "bob", types.ActionSmallBlind, 1)
+		act(t, game, "carol", types.ActionBigBlind, 2)
+		expectNext(t, game, "bob")
+		if game.CanDeal() {
+			t.Fatal("Expected no deal before the antes")
+		}
+		act(t, game, "bob", types.ActionAnte, 1)
+		act(t, game, "carol", types.ActionAnte, 1)
+		act(t, game, "alice", types.ActionAnte, 1)
+		game.Deal()
+
+		// Alice is all-in for her ante, so the action starts with bob
+		expectNext(t, game, "bob")
+		if bets := game.GetBets(types.RoundPreFlop); bets["bob"].Int64() != 1 || bets["carol"].Int64() != 2 || bets["alice"] != nil {
+			t.Errorf("Expected antes to stay out of the bets, got %v", bets)
+		}
+		act(t, game, "bob", types.ActionCall, 1)
+		act(t, game, "carol", types.ActionCheck, 0)
+
+		pots := game.GetPots()
+		if len(pots) != 2 || pots[0].Amount.Int64() != 3 || len(pots[0].Eligible) != 3 ||
+			pots[1].Amount.Int64() != 4 || len(pots[1].Eligible) != 2 {
+			t.Errorf("Expected a main pot of 3 and a side pot of 4, got %+v", pots)
+		}
+	})
+
+	t.Run("should collect a big blind ante from the big blind", func(t *testing.T) {
+		options := testOptions()
+		options.Ante = big.NewInt(2)
+		options.AnteType = types.AnteBigBlind
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100, 100)
+		postBlinds(t, game)
+
+		if chips(t, game, "carol") != 96 || game.GetPot().Int64() != 5 {
+			t.Errorf("Expected carol to post 4 into a pot of 5, got %d and %s", chips(t, game, "carol"), game.GetPot())
+		}
+		expectNext(t, game, "alice")
+	})
+
+	t.Run("should start the action left of the big blind after the antes", func(t *testing.T) {
+		options := testOptions()
+		options.Ante = big.NewInt(1)
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100, 100)
+		postBlinds(t, game)
+
+		expectNext(t, game, "alice")
+	})
+
+	t.Run("should reject an unknown ante type", func(t *testing.T) {
+		options := testOptions()
+		options.AnteType = "EVERYONE"
+		if _, err := NewTexasHoldem(options, ""); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions, got %v", err)
+		}
+	})
+}
+
+// TestTexasHoldem_Straddles tests UTG and button straddles
+func TestTexasHoldem_Straddles(t *testing.T) {
+	t.Run("should let the UTG straddle act last as the new big blind", func(t *testing.T) {
+		options := testOptions()
+		options.Straddle = types.StraddleUTG
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100, 100, 100)
+		postForced(t, game)
+
+		expectNext(t, game, "dave")
+		act(t, game, "dave", types.ActionStraddle, 4)
+		tableAct(t, game, "dave", types.ActionDeal, 0, "")
+
+		expectNext(t, game, "alice")
+		legal, err := game.GetLegalActions("alice")
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		for _, l := range legal {
+			if l.Action == types.ActionRaise && l.MinAmount.Int64() != 8 {
+				t.Errorf("Expected a minimum raise to 8, got %s", l.MinAmount)
+			}
+		}
+
+		act(t, game, "alice", types.ActionCall, 4)
+		act(t, game, "bob", types.ActionCall, 3)
+		act(t, game, "carol", types.ActionCall, 2)
+		expectNext(t, game, "dave")
+		act(t, game, "dave", types.ActionCheck, 0)
+		if game.GetCurrentRound() != types.RoundFlop || game.GetPot().Int64() != 16 {
+			t.Errorf("Expected a flop with 16 in the pot, got %s with %s", game.GetCurrentRound(), game.GetPot())
+		}
+	})
+
+	t.Run("should pass over a straddle when the cards are dealt", func(t *testing.T) {
+		options := testOptions()
+		options.Straddle = types.StraddleUTG
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100, 100, 100)
+		postForced(t, game)
+
+		if !game.CanDeal() {
+			t.Fatal("Expected the deal to be allowed without a straddle")
+		}
+		tableAct(t, game, "alice", types.ActionDeal, 0, "")
+		expectNext(t, game, "dave")
+		if err := game.PerformAction("dave", types.ActionStraddle, game.GetActionIndex(), big.NewInt(4)); !errors.Is(err, ErrIllegalAction) {
+			t.Errorf("Expected the straddle to be refused after the deal, got %v", err)
+		}
+	})
+
+	t.Run("should start the action with the small blind after a button straddle", func(t *testing.T) {
+		options := testOptions()
+		options.Straddle = types.StraddleButton
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100, 100, 100)
+		postForced(t, game)
+
+		expectNext(t, game, "alice")
+		act(t, game, "alice", types.ActionStraddle, 4)
+		game.Deal()
+
+		for _, address := range []string{"bob", "carol", "dave"} {
+			expectNext(t, game, address)
+			act(t, game, address, types.ActionFold, 0)
+		}
+		if game.GetCurrentRound() != types.RoundEnd || chips(t, game, "alice") != 103 {
+			t.Errorf("Expected alice to win the blinds, got a stack of %d", chips(t, game, "alice"))
+		}
+	})
+
+	t.Run("should reject straddles outside cash games", func(t *testing.T) {
+		options := testOptions()
+		options.Format = types.FormatTournament
+		options.Straddle = types.StraddleUTG
+		if _, err := NewTexasHoldem(options, ""); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions, got %v", err)
+		}
+	})
+}
+
+// TestTexasHoldem_BettingStructures tests pot-limit and fixed-limit tables
+func TestTexasHoldem_BettingStructures(t *testing.T) {
+	t.Run("should offer pot-limit raises up to the pot", func(t *testing.T) {
+		options := testOptions()
+		options.Betting = types.BettingPotLimit
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100, 100)
+		postBlinds(t, game)
+
+		legal, err := game.GetLegalActions("alice")
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		for _, l := range legal {
+			if l.Action == types.ActionRaise && (l.MinAmount.Int64() != 4 || l.MaxAmount.Int64() != 7) {
+				t.Errorf("Expected raises from 4 to 7, got %s to %s", l.MinAmount, l.MaxAmount)
+			}
+			if l.Action == types.ActionAllIn {
+				t.Error("Expected no all-in over the pot limit")
+			}
+		}
+	})
+
+	t.Run("should lift the fixed-limit cap when heads-up", func(t *testing.T) {
+		options := testOptions()
+		options.Betting = types.BettingFixedLimit
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100, 100)
+		postBlinds(t, game)
+
+		if game.GetRaiseCap() != 4 || game.GetFixedBet().Int64() != 2 {
+			t.Fatalf("Expected a cap of 4 and a small bet of 2, got %d and %s", game.GetRaiseCap(), game.GetFixedBet())
+		}
+		act(t, game, "alice", types.ActionFold, 0)
+		if game.GetRaiseCap() != 0 {
+			t.Errorf("Expected no cap heads-up, got %d", game.GetRaiseCap())
+		}
+		act(t, game, "bob", types.ActionCall, 1)
+		act(t, game, "carol", types.ActionCheck, 0)
+		act(t, game, "bob", types.ActionCheck, 0)
+		act(t, game, "carol", types.ActionCheck, 0)
+		if game.GetCurrentRound() != types.RoundTurn || game.GetFixedBet().Int64() != 4 {
+			t.Errorf("Expected a big bet of 4 on the turn, got %s in %s", game.GetFixedBet(), game.GetCurrentRound())
+		}
+	})
+
+	t.Run("should reject an unknown structure", func(t *testing.T) {
+		options := testOptions()
+		options.Betting = "SPREAD_LIMIT"
+		if _, err := NewTexasHoldem(options, ""); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions, got %v", err)
+		}
+	})
+}
+
+// TestTexasHoldem_ShortDeck tests dealing and ranking Short Deck hands
+func TestTexasHoldem_ShortDeck(t *testing.T) {
+	options := testOptions()
+	options.Variant = types.VariantShortDeck
+
+	t.Run("should reject a deck of the wrong size", func(t *testing.T) {
+		if _, err := NewTexasHoldem(options, stackedDeck(t, "")); err == nil {
+			t.Error("Expected a standard deck to be rejected for Short Deck")
+		}
+		if _, err := NewTexasHoldem(testOptions(), stackedDeckOf(t, models.ShortDeckComposition, "")); err == nil {
+			t.Error("Expected a short deck to be rejected for Hold'em")
+		}
+	})
+
+	t.Run("should rank a flush above a full house", func(t *testing.T) {
+		// Dealt from seat 2: bob AH, alice 9S, bob TH, alice 9C, then the board
+		deck := stackedDeckOf(t, models.ShortDeckComposition, "AH 9S TH 9C 6S KH KD 9H 6D 7H 8S 6C")
+		game := newTestGameWith(t, options, deck, 100, 100)
+		if game.Deck.Remaining() != 36 {
+			t.Fatalf("Expected 36 cards, got %d", game.Deck.Remaining())
+		}
+		postBlinds(t, game)
+		act(t, game, "alice", types.ActionCall, 1)
+		act(t, game, "bob", types.ActionCheck, 0)
+		for _, round := range []types.TexasHoldemRound{types.RoundFlop, types.RoundTurn, types.RoundRiver} {
+			if game.GetCurrentRound() != round {
+				t.Fatalf("Expected %s, got %s", round, game.GetCurrentRound())
+			}
+			act(t, game, "bob", types.ActionCheck, 0)
+			act(t, game, "alice", types.ActionCheck, 0)
+		}
+		act(t, game, "bob", types.ActionShow, 0)
+		act(t, game, "alice", types.ActionShow, 0)
+
+		winners := game.GetWinners()
+		if len(winners) != 1 || winners[0].Name != "bob" || winners[0].Description != "Flush, Ace High" {
+			t.Fatalf("Expected bob's flush to beat alice's full house, got %+v", winners)
+		}
+		if err := game.ReInit(stackedDeck(t, "")); err == nil {
+			t.Error("Expected the next hand to reject a standard deck")
+		}
+	})
+}
+
+// TestTexasHoldem_RunItTwice tests running the board more than once when all-in
+func TestTexasHoldem_RunItTwice(t *testing.T) {
+	// Dealt from seat 2: bob 7S, carol KH, alice AH, bob 8S, carol KS, alice AS.
+	// Carol's kings win the first board and alice's aces the second.
+	deck := stackedDeck(t, "7S KH AH 8S KS AS 2D KD 7C 8H 3D 2C 4D 9H 5D 3H 6C TD 6D JC 7H 4S")
+	allIn := func(t *testing.T) *TexasHoldem {
+		options := testOptions()
+		options.MaxRuns = 3
+		game := newTestGameWith(t, options, deck, 100, 100, 100)
+		postBlinds(t, game)
+		act(t, game, "alice", types.ActionAllIn, 100)
+		act(t, game, "bob", types.ActionFold, 0)
+		act(t, game, "carol", types.ActionAllIn, 98)
+		return game
+	}
+
+	t.Run("should split each pot between the boards with the odd chip to the first", func(t *testing.T) {
+		game := allIn(t)
+
+		expectNext(t, game, "carol")
+		legal, err := game.GetLegalActions("carol")
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		if len(legal) != 1 || legal[0].Action != types.ActionRunIt || legal[0].MaxAmount.Int64() != 3 {
+			t.Fatalf("Expected only RUN_IT up to 3 times, got %+v", legal)
+		}
+		act(t, game, "carol", types.ActionRunIt, 3)
+		act(t, game, "alice", types.ActionRunIt, 2)
+
+		boards := game.GetBoards()
+		if len(boards) != 2 || boards[0][0].Mnemonic != "KD" || boards[0][4].Mnemonic != "9H" ||
+			boards[1][0].Mnemonic != "3H" || boards[1][4].Mnemonic != "4S" {
+			t.Fatalf("Expected two boards, got %v", boards)
+		}
+		if game.GetPot().Int64() != 201 {
+			t.Errorf("Expected the run choices to add nothing to the pot, got %s", game.GetPot())
+		}
+
+		act(t, game, "carol", types.ActionShow, 0)
+		act(t, game, "alice", types.ActionShow, 0)
+		if chips(t, game, "carol") != 101 || chips(t, game, "alice") != 100 {
+			t.Errorf("Expected carol 101 and alice 100, got %d and %d", chips(t, game, "carol"), chips(t, game, "alice"))
+		}
+	})
+
+	t.Run("should run the board once if anyone chooses once", func(t *testing.T) {
+		game := allIn(t)
+		act(t, game, "carol", types.ActionRunIt, 1)
+		act(t, game, "alice", types.ActionRunIt, 3)
+
+		if len(game.GetBoards()) != 1 || game.GetCurrentRound() != types.RoundShowdown {
+			t.Fatalf("Expected a single board at showdown")
+		}
+		act(t, game, "carol", types.ActionShow, 0)
+		act(t, game, "alice", types.ActionShow, 0)
+		if chips(t, game, "carol") != 201 {
+			t.Errorf("Expected carol to win 201, got %d", chips(t, game, "carol"))
+		}
+	})
+
+	t.Run("should offer only the runs left in the deck from the flop", func(t *testing.T) {
+		options := testOptions()
+		options.MaxRuns = 20
+		game := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100)
+		postBlinds(t, game)
+		act(t, game, "alice", types.ActionCall, 1)
+		act(t, game, "bob", types.ActionCheck, 0)
+		act(t, game, "bob", types.ActionAllIn, 98)
+		act(t, game, "alice", types.ActionAllIn, 98)
+
+		// 44 cards are left and each run burns and deals the turn and river
+		if game.GetMaxRuns() != 11 {
+			t.Fatalf("Expected 11 runs from 44 cards, got %d", game.GetMaxRuns())
+		}
+		act(t, game, "bob", types.ActionRunIt, 11)
+		act(t, game, "alice", types.ActionRunIt, 11)
+
+		if game.GetCurrentRound() != types.RoundShowdown || len(game.GetBoards()) != 11 || game.GetDeck().Remaining() != 0 {
+			t.Fatalf("Expected 11 boards using the whole deck, got %d with %d cards left in %s",
+				len(game.GetBoards()), game.GetDeck().Remaining(), game.GetCurrentRound())
+		}
+		for _, board := range game.GetBoards() {
+			if len(board) != 5 {
+				t.Fatalf("Expected complete boards, got %v", board)
+			}
+		}
+	})
+
+	t.Run("should not offer a second run by default", func(t *testing.T) {
+		game := newTestGame(t, deck, 100, 100, 100)
+		postBlinds(t, game)
+		act(t, game, "alice", types.ActionAllIn, 100)
+		act(t, game, "bob", types.ActionFold, 0)
+		act(t, game, "carol", types.ActionAllIn, 98)
+
+		if game.GetCurrentRound() != types.RoundShowdown || game.GetMaxRuns() != 0 {
+			t.Errorf("Expected the board to run out once")
+		}
+	})
+}
+
+// TestTexasHoldem_BombPot plays a double-board bomb pot with a side pot
+func TestTexasHoldem_BombPot(t *testing.T) {
+	// Dealt from seat 2: bob KS KD, carol AS AD, alice 7H 8H. Each street burns
+	// and deals the first board, then burns and deals the second.
+	deck := stackedDeck(t, "KS AS 7H KD AD 8H 3C 2C 5D 9S 4D 6H 9H TH 5C JC 6C 2S 7C 3D 8C 4C")
+	game := newTestGame(t, deck, 100, 100, 15)
+	if err := game.SetHandOptions(types.HandOptions{BombPot: big.NewInt(10), Boards: 2}); err != nil {
+		t.Fatalf("SetHandOptions failed: %v", err)
+	}
+
+	t.Run("should take an ante from every player and no blinds", func(t *testing.T) {
+		for _, address := range []string{"bob", "carol", "alice"} {
+			post := game.GetNextPost()
+			if post == nil || post.Address != address || post.Action != types.ActionAnte || post.Amount.Int64() != 10 {
+				t.Fatalf("Expected an ante of 10 from %s, got %+v", address, post)
+			}
+			act(t, game, post.Address, post.Action, post.Amount.Int64())
+		}
+		game.Deal()
+	})
+
+	t.Run("should skip pre-flop betting and deal two flops", func(t *testing.T) {
+		boards := game.GetBoards()
+		if game.GetCurrentRound() != types.RoundFlop || len(boards) != 2 ||
+			boards[0][0].Mnemonic != "2C" || boards[1][0].Mnemonic != "6H" || len(boards[1]) != 3 {
+			t.Fatalf("Expected two flops, got %v in %s", boards, game.GetCurrentRound())
+		}
+		if game.GetPot().Int64() != 30 {
+			t.Errorf("Expected 30 in antes, got %s", game.GetPot())
+		}
+		expectNext(t, game, "bob")
+	})
+
+	t.Run("should split each pot between the board winners", func(t *testing.T) {
+		act(t, game, "bob", types.ActionBet, 20)
+		act(t, game, "carol", types.ActionAllIn, 5)
+		act(t, game, "alice", types.ActionCall, 20)
+		for game.GetCurrentRound() != types.RoundShowdown {
+			next, err := game.GetNextPlayerToAct()
+			if err != nil {
+				t.Fatalf("GetNextPlayerToAct failed: %v", err)
+			}
+			act(t, game, next.GetAddress(), types.ActionCheck, 0)
+		}
+		if boards := game.GetBoards(); len(boards[0]) != 5 || boards[1][4].Mnemonic != "4C" {
+			t.Fatalf("Expected both boards run out, got %v", boards)
+		}
+		for _, address := range []string{"bob", "carol", "alice"} {
+			act(t, game, address, types.ActionShow, 0)
+		}
+
+		// Carol's aces win half the main pot of 45 with the odd chip, and
+		// bob's kings half the side pot of 30. Alice's straight flush wins
+		// the second board.
+		if chips(t, game, "carol") != 23 || chips(t, game, "bob") != 85 || chips(t, game, "alice") != 107 {
+			t.Errorf("Expected stacks 107/85/23, got %d/%d/%d",
+				chips(t, game, "alice"), chips(t, game, "bob"), chips(t, game, "carol"))
+		}
+	})
+
+	t.Run("should deal the next hand as normal", func(t *testing.T) {
+		if err := game.ReInit(stackedDeck(t, "")); err != nil {
+			t.Fatalf("ReInit failed: %v", err)
+		}
+		if !game.GetHandOptions().IsStandard() || game.GetNextPost().Action != types.ActionSmallBlind {
+			t.Errorf("Expected blinds in the next hand, got %+v", game.GetNextPost())
+		}
+	})
+}
+
+// TestTexasHoldem_SetHandOptions tests hand option validation
+func TestTexasHoldem_SetHandOptions(t *testing.T) {
+	options := testOptions()
+	options.Format = types.FormatTournament
+	tournament := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100)
+	if err := tournament.SetHandOptions(types.HandOptions{BombPot: big.NewInt(5)}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions for a tournament bomb pot, got %v", err)
+	}
+
+	options = testOptions()
+	options.Variant = types.VariantOmaha
+	omaha := newTestGameWith(t, options, stackedDeck(t, ""), 100, 100)
+	if err := omaha.SetHandOptions(types.HandOptions{Boards: 3}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions for three boards at a 9-max Omaha table, got %v", err)
+	}
+
+	game := newTestGame(t, stackedDeck(t, ""), 100, 100)
+	if err := game.SetHandOptions(types.HandOptions{BombPot: big.NewInt(-1)}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions for a negative bomb pot, got %v", err)
+	}
+	postBlinds(t, game)
+	if err := game.SetHandOptions(types.HandOptions{Boards: 2}); !errors.Is(err, ErrHandInProgress) {
+		t.Errorf("Expected ErrHandInProgress, got %v", err)
+	}
+}
+
+// TestTexasHoldem_PerformActionErrors tests rejected actions
+func TestTexasHoldem_PerformActionErrors(t *testing.T) {
+	game := newTestGame(t, stackedDeck(t, ""), 100, 100, 100)
+	postBlinds(t, game)
+
+	tests := []struct {
+		name    string
+		address string
+		action  types.PlayerActionType
+		index   int
+		amount  int64
+		err     error
+	}{
+		{"unknown player", "mallory", types.ActionFold, 3, 0, ErrPlayerNotFound},
+		{"stale index", "alice", types.ActionCall, 2, 2, ErrInvalidIndex},
+		{"out of turn", "bob", types.ActionCall, 3, 1, ErrNotYourTurn},
+		{"check facing a bet", "alice", types.ActionCheck, 3, 0, ErrIllegalAction},
+		{"short call", "alice", types.ActionCall, 3, 1, ErrInvalidAmount},
+		{"raise below minimum", "alice", types.ActionRaise, 3, 3, ErrInvalidAmount},
+		{"raise above stack", "alice", types.ActionRaise, 3, 101, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			err := game.PerformAction(tt.address, tt.action, tt.index, big.NewInt(tt.amount))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+
+	t.Run("should not start a new hand mid-hand", func(t *testing.T) {
+		if err := game.ReInit(stackedDeck(t, "")); !errors.Is(err, ErrHandInProgress) {
+			t.Errorf("Expected ErrHandInProgress, got %v", err)
+		}
+	})
+}
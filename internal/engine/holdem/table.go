@@ -0,0 +1,68 @@
+package holdem
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// PerformNonPlayerAction applies a table action. For JOIN the amount is the
+// buy-in and data the seat, empty for the first free seat; for NEW_HAND data
+// is the deck string for the next hand.
+func (g *TexasHoldem) PerformNonPlayerAction(address string, action types.NonPlayerActionType, index int, amount *big.Int, data string) error {
+	var player types.IPlayer
+	if action == types.ActionJoin {
+		player = models.NewPlayer(address, big.NewInt(0), 0)
+	} else {
+		p, err := g.GetPlayer(address)
+		if err != nil {
+			return err
+		}
+		player = p
+	}
+
+	a, err := actions.NewNonPlayerAction(g, action, data)
+	if err != nil {
+		return err
+	}
+	if err := a.Execute(player, index, amount); err != nil {
+		return err
+	}
+	return g.advance()
+}
+
+// RemovePlayer takes a player off the table. Tournament chips cannot be
+// cashed out, so a player leaving a tournament sits out instead and is
+// blinded off until knocked out.
+func (g *TexasHoldem) RemovePlayer(address string) error {
+	if g.Options.Format != types.FormatCash {
+		return g.SetSittingOut(address, true)
+	}
+	return g.Game.RemovePlayer(address)
+}
+
+// IsSittingOut reports whether a player is sitting out or will sit out from
+// the next hand
+func (g *TexasHoldem) IsSittingOut(address string) bool {
+	return g.Game.IsSittingOut(address) || g.away[address]
+}
+
+// SetSittingOut sits a player out or back in. In a tournament a player
+// sitting out is still dealt in: they post their blinds and antes, check
+// when they can and otherwise fold, so their stack blinds off.
+func (g *TexasHoldem) SetSittingOut(address string, out bool) error {
+	if g.Options.Format == types.FormatCash {
+		return g.Game.SetSittingOut(address, out)
+	}
+	if _, err := g.GetPlayer(address); err != nil {
+		return err
+	}
+	if out {
+		g.away[address] = true
+	} else {
+		delete(g.away, address)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package holdem
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// tableAct performs a non-player action with the next index, failing the test on error
+func tableAct(t *testing.T, game *TexasHoldem, address string, action types.NonPlayerActionType, amount int64, data string) {
+	t.Helper()
+
+	if err := game.PerformNonPlayerAction(address, action, game.GetActionIndex(), big.NewInt(amount), data); err != nil {
+		t.Fatalf("%s %s failed: %v", address, action, err)
+	}
+}
+
+// TestTexasHoldem_Join tests seating through the JOIN action
+func TestTexasHoldem_Join(t *testing.T) {
+	t.Run("should seat players in a chosen or the first free seat", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""))
+		tableAct(t, game, "alice", types.ActionJoin, 100, "3")
+		tableAct(t, game, "bob", types.ActionJoin, 100, "")
+
+		if game.GetPlayerSeatNumber("alice") != 3 || game.GetPlayerSeatNumber("bob") != 1 {
+			t.Errorf("Expected alice in seat 3 and bob in seat 1")
+		}
+		if chips(t, game, "alice") != 100 {
+			t.Errorf("Expected a stack of 100, got %d", chips(t, game, "alice"))
+		}
+	})
+
+	t.Run("should bound the buy-in by the table minimum and maximum", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""))
+
+		for _, amount := range []int64{39, 201} {
+			err := game.PerformNonPlayerAction("alice", types.ActionJoin, 1, big.NewInt(amount), "")
+			if !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("Expected ErrInvalidAmount for %d, got %v", amount, err)
+			}
+		}
+	})
+
+	t.Run("should reject taken seats and seated players", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100)
+
+		err := game.PerformNonPlayerAction("bob", types.ActionJoin, 1, big.NewInt(100), "1")
+		if !errors.Is(err, actions.ErrInvalidSeat) {
+			t.Errorf("Expected ErrInvalidSeat, got %v", err)
+		}
+		err = game.PerformNonPlayerAction("alice", types.ActionJoin, 1, big.NewInt(100), "2")
+		if !errors.Is(err, actions.ErrAlreadySeated) {
+			t.Errorf("Expected ErrAlreadySeated, got %v", err)
+		}
+	})
+
+	t.Run("should reject a full table", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100, 100, 100, 100, 100, 100, 100, 100, 100)
+
+		err := game.PerformNonPlayerAction("zoe", types.ActionJoin, 1, big.NewInt(100), "")
+		if !errors.Is(err, actions.ErrTableFull) {
+			t.Errorf("Expected ErrTableFull, got %v", err)
+		}
+	})
+
+	t.Run("should deal a player joining mid-hand into the next hand", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100, 100)
+		postBlinds(t, game)
+		tableAct(t, game, "carol", types.ActionJoin, 100, "")
+
+		carol, _ := game.GetPlayer("carol")
+		if len(carol.HoleCards) != 0 || game.Contesting(carol) {
+			t.Errorf("Expected carol to wait for the next hand")
+		}
+	})
+}
+
+// TestTexasHoldem_Leave tests leaving between and during hands
+func TestTexasHoldem_Leave(t *testing.T) {
+	t.Run("should free the seat at once between hands", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100, 100)
+		tableAct(t, game, "bob", types.ActionLeave, 0, "")
+
+		if game.GetPlayerSeatNumber("bob") != 0 {
+			t.Error("Expected bob to leave")
+		}
+		log := game.GetActionLog()
+		if len(log) != 1 || log[0].Action != types.ActionLeave || log[0].Amount.Int64() != 100 || log[0].Seat != 2 {
+			t.Errorf("Expected a leave turn cashing out 100 from seat 2, got %+v", log)
+		}
+	})
+
+	t.Run("should fold a player leaving mid-hand and cash out after the hand", func(t *testing.T) {
+		game := newTestGame(t, stackedDeck(t, ""), 100, 100, 100)
+		postBlinds(t, game)
+		act(t, game, "alice", types.ActionCall, 2)
+		tableAct(t, game, "alice", types.ActionLeave, 0, "")
+
+		alice, _ := game.GetPlayer("alice")
+		if alice.Status != types.StatusFolded {
+			t.Errorf("Expected alice to be folded, got %s", alice.Status)
+		}
+		expectNext(t, game, "bob")
+
+		act(t, game, "bob", types.ActionFold, 0)
+		if game.GetCurrentRound() != types.RoundEnd || chips(t, game, "carol") != 103 {
+			t.Fatalf("Expected carol to win the pot")
+		}
+
+		tableAct(t, game, "carol", types.ActionNewHand, 0, stackedDeck(t, ""))
+		if game.GetPlayerSeatNumber("alice") != 0 {
+			t.Error("Expected alice's seat to be freed for the next hand")
+		}
+	})
+}
+
+// TestTexasHoldem_DeadSmallBlind tests the blinds when the small blind leaves between hands
+func TestTexasHoldem_DeadSmallBlind(t *testing.T) {
+	game := newTestGame(t, stackedDeck(t, ""), 100, 100, 100, 100)
+	postBlinds(t, game)
+	act(t, game, "dave", types.ActionFold, 0)
+	act(t, game, "alice", types.ActionFold, 0)
+	act(t, game, "bob", types.ActionFold, 0)
+	tableAct(t, game, "carol", types.ActionNewHand, 0, stackedDeck(t, ""))
+	tableAct(t, game, "carol", types.ActionLeave, 0, "")
+
+	if game.GetDealerPosition() != 2 || game.GetSmallBlindPosition() != 3 || game.GetBigBlindPosition() != 4 {
+		t.Fatalf("Expected button 2 and blinds 3/4, got %d and %d/%d",
+			game.GetDealerPosition(), game.GetSmallBlindPosition(), game.GetBigBlindPosition())
+	}
+	if !game.HasDeadSmallBlind() {
+		t.Fatal("Expected a dead small blind")
+	}
+
+	expectNext(t, game, "dave")
+	act(t, game, "dave", types.ActionBigBlind, 2)
+	tableAct(t, game, "dave", types.ActionDeal, 0, "")
+	expectNext(t, game, "alice")
+	if game.GetPot().Int64() != 2 {
+		t.Errorf("Expected a pot of 2, got %s", game.GetPot())
+	}
+}
+
+// TestTexasHoldem_SitOut tests sitting out and back in
+func TestTexasHoldem_SitOut(t *testing.T) {
+	game := newTestGame(t, stackedDeck(t, ""), 100, 100, 100)
+	postBlinds(t, game)
+
+	t.Run("should sit out from the next hand when in a hand", func(t *testing.T) {
+		tableAct(t, game, "alice", types.ActionSitOut, 0, "")
+
+		alice, _ := game.GetPlayer("alice")
+		if alice.Status != types.StatusActive || !game.IsSittingOut("alice") {
+			t.Errorf("Expected alice to finish the hand, got %s", alice.Status)
+		}
+		if err := game.PerformNonPlayerAction("alice", types.ActionSitOut, game.GetActionIndex(), nil, ""); !errors.Is(err, ErrIllegalAction) {
+			t.Errorf("Expected ErrIllegalAction, got %v", err)
+		}
+	})
+
+	t.Run("should not deal a sitting-out player", func(t *testing.T) {
+		act(t, game, "alice", types.ActionFold, 0)
+		act(t, game, "bob", types.ActionFold, 0)
+		tableAct(t, game, "bob", types.ActionNewHand, 0, stackedDeck(t, ""))
+
+		alice, _ := game.GetPlayer("alice")
+		if alice.Status != types.StatusSittingOut || game.InHand(alice) {
+			t.Errorf("Expected alice to sit out, got %s", alice.Status)
+		}
+	})
+
+	t.Run("should deal a player back in after sitting in", func(t *testing.T) {
+		tableAct(t, game, "alice", types.ActionSitIn, 0, "")
+
+		alice, _ := game.GetPlayer("alice")
+		if alice.Status != types.StatusActive || !game.InHand(alice) {
+			t.Errorf("Expected alice to be dealt in, got %s", alice.Status)
+		}
+	})
+}
+
+// TestTexasHoldem_DealAndNewHand tests the DEAL and NEW_HAND actions and the action log
+func TestTexasHoldem_DealAndNewHand(t *testing.T) {
+	game := newTestGame(t, stackedDeck(t, ""))
+	tableAct(t, game, "alice", types.ActionJoin, 100, "")
+	tableAct(t, game, "bob", types.ActionJoin, 100, "")
+
+	if err := game.PerformNonPlayerAction("alice", types.ActionDeal, game.GetActionIndex(), nil, ""); !errors.Is(err, actions.ErrInvalidRound) {
+		t.Errorf("Expected ErrInvalidRound before the blinds, got %v", err)
+	}
+
+	act(t, game, "alice", types.ActionSmallBlind, 1)
+	act(t, game, "bob", types.ActionBigBlind, 2)
+	tableAct(t, game, "bob", types.ActionDeal, 0, "")
+
+	alice, _ := game.GetPlayer("alice")
+	if len(alice.HoleCards) != 2 {
+		t.Fatalf("Expected hole cards after DEAL")
+	}
+
+	if err := game.PerformNonPlayerAction("alice", types.ActionNewHand, game.GetActionIndex(), nil, ""); !errors.Is(err, actions.ErrInvalidRound) {
+		t.Errorf("Expected ErrInvalidRound mid-hand, got %v", err)
+	}
+	act(t, game, "alice", types.ActionFold, 0)
+	tableAct(t, game, "bob", types.ActionNewHand, 0, stackedDeck(t, "AS KS"))
+
+	if game.GetHandNumber() != 2 || game.GetDeck().GetTop() != 0 {
+		t.Errorf("Expected hand 2 with a fresh deck")
+	}
+
+	expected := []interface{}{
+		types.ActionJoin, types.ActionJoin,
+		types.ActionSmallBlind, types.ActionBigBlind, types.ActionDeal,
+		types.ActionFold, types.ActionNewHand,
+	}
+	log := game.GetActionLog()
+	if len(log) != len(expected) {
+		t.Fatalf("Expected %d turns, got %d", len(expected), len(log))
+	}
+	for i, turn := range log {
+		if turn.Action != expected[i] || turn.Index != i+1 {
+			t.Errorf("Turn %d: expected %v, got %v at index %d", i+1, expected[i], turn.Action, turn.Index)
+		}
+	}
+}
+
+// TestTexasHoldem_NewHandOptions tests starting a bomb pot with NEW_HAND
+func TestTexasHoldem_NewHandOptions(t *testing.T) {
+	game := newTestGame(t, stackedDeck(t, ""), 100, 100)
+	postBlinds(t, game)
+	act(t, game, "alice", types.ActionFold, 0)
+
+	err := game.PerformNonPlayerAction("bob", types.ActionNewHand, game.GetActionIndex(), nil, stackedDeck(t, "")+"?straddle=1")
+	if !errors.Is(err, actions.ErrHandOptions) {
+		t.Errorf("Expected ErrHandOptions, got %v", err)
+	}
+
+	tableAct(t, game, "bob", types.ActionNewHand, 0, stackedDeck(t, "")+"?bombPot=5&boards=2")
+	if options := game.GetHandOptions(); options.BombPot.Int64() != 5 || options.Boards != 2 {
+		t.Fatalf("Expected a double-board bomb pot of 5, got %+v", options)
+	}
+	if post := game.GetNextPost(); post == nil || post.Action != types.ActionAnte || post.Amount.Int64() != 5 {
+		t.Errorf("Expected an ante of 5, got %+v", post)
+	}
+}
@@ -0,0 +1,173 @@
+package managers
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// BetManager summarises a single betting round by replaying its turns.
+// Blinds and straddles count towards a player's bet but are not actions, so
+// the big blind or straddler still gets an option when everyone calls. Antes are dead money and are
+// kept apart from the bets. A stud bring-in is an action that opens the
+// betting for less than a full bet.
+type BetManager struct {
+	bets      map[string]*big.Int
+	antes     map[string]*big.Int
+	largest   *big.Int
+	minRaise  *big.Int        // Size of the last full bet or raise
+	acted     map[string]bool // Players who have acted since the last full raise
+	aggressor string
+	raises    int // Full bets and raises, counting the big blind and a straddle
+}
+
+// NewBetManager replays turns from a round. The minimum raise starts at the
+// big blind.
+func NewBetManager(bigBlind *big.Int, turns []types.Turn) *BetManager {
+	m := &BetManager{
+		bets:     make(map[string]*big.Int),
+		antes:    make(map[string]*big.Int),
+		largest:  big.NewInt(0),
+		minRaise: new(big.Int).Set(bigBlind),
+		acted:    make(map[string]bool),
+	}
+	for _, turn := range turns {
+		m.Add(turn)
+	}
+	return m
+}
+
+// Add applies a turn to the round
+func (m *BetManager) Add(turn types.Turn) {
+	action, ok := turn.Action.(types.PlayerActionType)
+	if !ok {
+		return
+	}
+
+	switch action {
+	case types.ActionAnte:
+		ante := m.GetAnte(turn.PlayerID)
+		if turn.Amount != nil {
+			ante.Add(ante, turn.Amount)
+		}
+		m.antes[turn.PlayerID] = ante
+	case types.ActionSmallBlind, types.ActionBigBlind, types.ActionStraddle:
+		m.addChips(turn)
+	case types.ActionBringIn, types.ActionCall, types.ActionBet, types.ActionRaise, types.ActionAllIn:
+		m.addChips(turn)
+		m.acted[turn.PlayerID] = true
+	default:
+		m.acted[turn.PlayerID] = true
+	}
+}
+
+// addChips adds a turn's amount to the player's bet, tracking raises
+func (m *BetManager) addChips(turn types.Turn) {
+	bet := m.GetBet(turn.PlayerID)
+	if turn.Amount != nil {
+		bet.Add(bet, turn.Amount)
+	}
+	m.bets[turn.PlayerID] = bet
+
+	if bet.Cmp(m.largest) <= 0 {
+		return
+	}
+
+	previous := m.largest
+	increase := new(big.Int).Sub(bet, previous)
+	m.largest = new(big.Int).Set(bet)
+
+	// Blinds set the price without reopening the action. A straddle acts as
+	// a new big blind, so raises must be at least its size.
+	action := turn.Action.(types.PlayerActionType)
+	switch action {
+	case types.ActionSmallBlind:
+		return
+	case types.ActionBigBlind:
+		m.raises++
+		return
+	case types.ActionBringIn:
+		if bet.Cmp(m.minRaise) < 0 {
+			return
+		}
+	case types.ActionStraddle:
+		m.raises++
+		if bet.Cmp(m.minRaise) > 0 {
+			m.minRaise = new(big.Int).Set(bet)
+		}
+		return
+	}
+
+	m.aggressor = turn.PlayerID
+
+	// A full raise reopens the action for everyone else, as does completing
+	// a bet that was short of the minimum, such as a bring-in
+	if increase.Cmp(m.minRaise) >= 0 || (previous.Cmp(m.minRaise) < 0 && bet.Cmp(m.minRaise) >= 0) {
+		m.raises++
+		if increase.Cmp(m.minRaise) > 0 {
+			m.minRaise = increase
+		}
+		m.acted = make(map[string]bool)
+	}
+}
+
+// GetBet returns a copy of the player's total bet this round
+func (m *BetManager) GetBet(address string) *big.Int {
+	if bet, ok := m.bets[address]; ok {
+		return new(big.Int).Set(bet)
+	}
+	return big.NewInt(0)
+}
+
+// GetBets returns a copy of every player's total bet this round
+func (m *BetManager) GetBets() map[string]*big.Int {
+	bets := make(map[string]*big.Int, len(m.bets))
+	for address, bet := range m.bets {
+		bets[address] = new(big.Int).Set(bet)
+	}
+	return bets
+}
+
+// GetAnte returns a copy of the ante the player posted this round
+func (m *BetManager) GetAnte(address string) *big.Int {
+	if ante, ok := m.antes[address]; ok {
+		return new(big.Int).Set(ante)
+	}
+	return big.NewInt(0)
+}
+
+// GetAntes returns a copy of every player's ante this round
+func (m *BetManager) GetAntes() map[string]*big.Int {
+	antes := make(map[string]*big.Int, len(m.antes))
+	for address, ante := range m.antes {
+		antes[address] = new(big.Int).Set(ante)
+	}
+	return antes
+}
+
+// GetLargestBet returns the amount every player must match to stay in
+func (m *BetManager) GetLargestBet() *big.Int {
+	return new(big.Int).Set(m.largest)
+}
+
+// GetMinRaise returns the smallest legal raise increment
+func (m *BetManager) GetMinRaise() *big.Int {
+	return new(big.Int).Set(m.minRaise)
+}
+
+// GetRaises returns the number of full bets and raises this round. Pre-flop
+// the big blind counts as the opening bet and a straddle as a raise.
+func (m *BetManager) GetRaises() int {
+	return m.raises
+}
+
+// HasActed reports whether the player has acted since the last full raise.
+// Such a player may call or fold an incomplete all-in raise but not re-raise.
+func (m *BetManager) HasActed(address string) bool {
+	return m.acted[address]
+}
+
+// GetAggressor returns the last player to bet or raise, if any
+func (m *BetManager) GetAggressor() string {
+	return m.aggressor
+}
@@ -0,0 +1,96 @@
+package managers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// turn builds a turn moving amount chips
+func turn(address string, action types.PlayerActionType, amount int64) types.Turn {
+	return types.Turn{PlayerID: address, Action: action, Amount: big.NewInt(amount)}
+}
+
+// TestBetManager tests replaying a betting round
+func TestBetManager(t *testing.T) {
+	t.Run("should count blinds without marking players as acted", func(t *testing.T) {
+		m := NewBetManager(big.NewInt(2), []types.Turn{
+			turn("alice", types.ActionSmallBlind, 1),
+			turn("bob", types.ActionBigBlind, 2),
+		})
+
+		if m.GetLargestBet().Int64() != 2 || m.GetBet("alice").Int64() != 1 {
+			t.Errorf("Expected largest bet 2 and alice 1, got %s and %s", m.GetLargestBet(), m.GetBet("alice"))
+		}
+		if m.HasActed("bob") {
+			t.Error("Expected the big blind to keep the option")
+		}
+		if m.GetMinRaise().Int64() != 2 {
+			t.Errorf("Expected min raise 2, got %s", m.GetMinRaise())
+		}
+	})
+
+	t.Run("should size raises from a straddle", func(t *testing.T) {
+		m := NewBetManager(big.NewInt(2), []types.Turn{
+			turn("alice", types.ActionSmallBlind, 1),
+			turn("bob", types.ActionBigBlind, 2),
+			turn("carol", types.ActionStraddle, 4),
+		})
+
+		if m.GetLargestBet().Int64() != 4 || m.GetMinRaise().Int64() != 4 {
+			t.Errorf("Expected largest bet 4 and min raise 4, got %s and %s", m.GetLargestBet(), m.GetMinRaise())
+		}
+		if m.HasActed("carol") || m.GetAggressor() != "" || m.GetRaises() != 2 {
+			t.Error("Expected the straddler to keep the option and the straddle to count as a raise")
+		}
+	})
+
+	t.Run("should reopen the action when a bring-in is completed", func(t *testing.T) {
+		m := NewBetManager(big.NewInt(4), []types.Turn{
+			turn("alice", types.ActionBringIn, 1),
+			turn("bob", types.ActionCall, 1),
+		})
+		if !m.HasActed("alice") || m.GetRaises() != 0 {
+			t.Fatal("Expected the bring-in to act without counting as a bet")
+		}
+
+		m.Add(turn("carol", types.ActionRaise, 4))
+		if m.HasActed("alice") || m.GetRaises() != 1 || m.GetMinRaise().Int64() != 4 {
+			t.Errorf("Expected the completion to reopen the action as the first bet, got %d bets and min raise %s",
+				m.GetRaises(), m.GetMinRaise())
+		}
+	})
+
+	t.Run("should reopen the action on a full raise", func(t *testing.T) {
+		m := NewBetManager(big.NewInt(2), []types.Turn{
+			turn("alice", types.ActionBet, 10),
+			turn("bob", types.ActionCall, 10),
+			turn("carol", types.ActionRaise, 30),
+		})
+
+		if m.GetMinRaise().Int64() != 20 || m.GetAggressor() != "carol" || m.GetRaises() != 2 {
+			t.Errorf("Expected the second raise, min 20 by carol, got raise %d, min %s by %s",
+				m.GetRaises(), m.GetMinRaise(), m.GetAggressor())
+		}
+		if m.HasActed("alice") || m.HasActed("bob") || !m.HasActed("carol") {
+			t.Error("Expected only carol to have acted since the raise")
+		}
+	})
+
+	t.Run("should not reopen the action on an incomplete all-in", func(t *testing.T) {
+		m := NewBetManager(big.NewInt(2), []types.Turn{
+			turn("alice", types.ActionBet, 10),
+			turn("bob", types.ActionCall, 10),
+			turn("carol", types.ActionAllIn, 15),
+		})
+
+		if m.GetLargestBet().Int64() != 15 || m.GetMinRaise().Int64() != 10 || m.GetRaises() != 1 {
+			t.Errorf("Expected largest 15 and min raise 10 after one bet, got %s and %s after %d",
+				m.GetLargestBet(), m.GetMinRaise(), m.GetRaises())
+		}
+		if !m.HasActed("alice") || !m.HasActed("bob") {
+			t.Error("Expected alice and bob to remain acted")
+		}
+	})
+}
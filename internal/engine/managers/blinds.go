@@ -0,0 +1,177 @@
+package managers
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Post is a forced bet due from a player before the cards are dealt
+type Post struct {
+	Address  string
+	Action   types.PlayerActionType
+	Amount   *big.Int
+	Optional bool // A straddle the player may post or let pass by the deal
+}
+
+// BlindsManager works out the forced bets of a hand. The small blind is
+// posted first, then the big blind, then the antes starting left of the
+// button. Blinds take priority over antes, so a short stack posts its blind
+// first, and a player who cannot cover a forced bet posts what they have and
+// is all-in. Antes are dead money: they go in the pot but do not count
+// towards a player's bet. A straddle of two big blinds may then be posted,
+// but only by a player who can cover it and with three or more players.
+type BlindsManager struct {
+	smallBlind *big.Int
+	bigBlind   *big.Int
+	ante       *big.Int
+	anteType   types.AnteType
+	straddle   types.StraddleType
+}
+
+// NewBlindsManager creates a blinds manager from the table options
+func NewBlindsManager(options types.GameOptions) *BlindsManager {
+	m := &BlindsManager{
+		smallBlind: options.SmallBlind,
+		bigBlind:   options.BigBlind,
+		ante:       options.Ante,
+		anteType:   options.AnteType,
+		straddle:   options.Straddle,
+	}
+	if m.anteType == "" {
+		m.anteType = types.AnteClassic
+	}
+	return m
+}
+
+// HasAnte reports whether the table posts antes
+func (m *BlindsManager) HasAnte() bool {
+	return m.ante != nil && m.ante.Sign() > 0
+}
+
+// GetNextPost returns the next forced bet due, or nil once every forced bet
+// is in. Players are those dealt in, ordered from the first seat left of
+// the button. The button and blind addresses are empty when the seat is
+// dead. Turns are the pre-flop turns so far.
+func (m *BlindsManager) GetNextPost(players []types.IPlayer, button, smallBlind, bigBlind string, turns []types.Turn) *Post {
+	byAddress := make(map[string]types.IPlayer, len(players))
+	for _, p := range players {
+		byAddress[p.GetAddress()] = p
+	}
+
+	due := func(address string, action types.PlayerActionType, amount *big.Int) *Post {
+		p, ok := byAddress[address]
+		if !ok || p.GetChips().Sign() <= 0 || posted(turns, address, action) {
+			return nil
+		}
+		if p.GetChips().Cmp(amount) < 0 {
+			amount = p.GetChips()
+		}
+		return &Post{Address: address, Action: action, Amount: new(big.Int).Set(amount)}
+	}
+
+	if post := due(smallBlind, types.ActionSmallBlind, m.smallBlind); post != nil {
+		return post
+	}
+	if post := due(bigBlind, types.ActionBigBlind, m.bigBlind); post != nil {
+		return post
+	}
+	if m.HasAnte() {
+		for _, address := range m.anteFrom(players, button, bigBlind) {
+			if post := due(address, types.ActionAnte, m.ante); post != nil {
+				return post
+			}
+		}
+	}
+
+	address := m.GetStraddler(players, button, bigBlind)
+	if address == "" || posted(turns, address, types.ActionStraddle) {
+		return nil
+	}
+	amount := m.GetStraddle()
+	if byAddress[address].GetChips().Cmp(amount) < 0 {
+		return nil
+	}
+	return &Post{Address: address, Action: types.ActionStraddle, Amount: amount, Optional: true}
+}
+
+// GetBombPotPost returns the next bomb pot ante due, or nil once every player
+// has posted. Every player antes the same amount, starting left of the button,
+// and nobody posts a blind.
+func (m *BlindsManager) GetBombPotPost(players []types.IPlayer, ante *big.Int, turns []types.Turn) *Post {
+	for _, p := range players {
+		if p.GetChips().Sign() <= 0 || posted(turns, p.GetAddress(), types.ActionAnte) {
+			continue
+		}
+		amount := ante
+		if p.GetChips().Cmp(amount) < 0 {
+			amount = p.GetChips()
+		}
+		return &Post{Address: p.GetAddress(), Action: types.ActionAnte, Amount: new(big.Int).Set(amount)}
+	}
+	return nil
+}
+
+// GetStraddle returns the size of a straddle, two big blinds
+func (m *BlindsManager) GetStraddle() *big.Int {
+	return new(big.Int).Mul(m.bigBlind, big.NewInt(2))
+}
+
+// GetStraddler returns the player who may straddle, or an empty string if
+// nobody may. Players are ordered from the first seat left of the button.
+func (m *BlindsManager) GetStraddler(players []types.IPlayer, button, bigBlind string) string {
+	if len(players) < 3 {
+		return ""
+	}
+
+	switch m.straddle {
+	case types.StraddleUTG:
+		for i, p := range players {
+			if p.GetAddress() == bigBlind {
+				return players[(i+1)%len(players)].GetAddress()
+			}
+		}
+	case types.StraddleButton:
+		for _, p := range players {
+			if p.GetAddress() == button {
+				return button
+			}
+		}
+	}
+	return ""
+}
+
+// anteFrom returns the players who owe an ante, in posting order. With a
+// dead button the button ante falls to the first player left of it.
+func (m *BlindsManager) anteFrom(players []types.IPlayer, button, bigBlind string) []string {
+	switch m.anteType {
+	case types.AnteBigBlind:
+		return []string{bigBlind}
+	case types.AnteButton:
+		for _, p := range players {
+			if p.GetAddress() == button {
+				return []string{button}
+			}
+		}
+		if len(players) > 0 {
+			return []string{players[0].GetAddress()}
+		}
+		return nil
+	default:
+		addresses := make([]string, len(players))
+		for i, p := range players {
+			addresses[i] = p.GetAddress()
+		}
+		return addresses
+	}
+}
+
+// posted reports whether a player has posted a forced bet
+func posted(turns []types.Turn, address string, action types.PlayerActionType) bool {
+	for _, turn := range turns {
+		if turn.Action == action && turn.PlayerID == address {
+			return true
+		}
+	}
+	return false
+}
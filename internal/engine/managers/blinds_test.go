@@ -0,0 +1,173 @@
+package managers
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// TestBlindsManager tests the order and size of blinds and antes
+func TestBlindsManager(t *testing.T) {
+	tests := []struct {
+		name     string
+		ante     int64
+		anteType types.AnteType
+		straddle types.StraddleType
+		stacks   []int64 // bob, carol and alice, left of the button
+		button   string
+		sb       string
+		want     []string
+	}{
+		{
+			name:   "should post the blinds without antes",
+			stacks: []int64{100, 100, 100},
+			want:   []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2"},
+		},
+		{
+			name:   "should post classic antes from left of the button",
+			ante:   1,
+			stacks: []int64{100, 100, 100},
+			want: []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2",
+				"bob POST_ANTE 1", "carol POST_ANTE 1", "alice POST_ANTE 1"},
+		},
+		{
+			name:     "should post one big blind ante",
+			ante:     2,
+			anteType: types.AnteBigBlind,
+			stacks:   []int64{100, 100, 100},
+			want:     []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2", "carol POST_ANTE 2"},
+		},
+		{
+			name:     "should post one button ante",
+			ante:     2,
+			anteType: types.AnteButton,
+			stacks:   []int64{100, 100, 100},
+			want:     []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2", "alice POST_ANTE 2"},
+		},
+		{
+			name:     "should pass a dead button's ante to the next player",
+			ante:     2,
+			anteType: types.AnteButton,
+			stacks:   []int64{100, 100, 100},
+			button:   "-",
+			want:     []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2", "bob POST_ANTE 2"},
+		},
+		{
+			name:     "should post the big blind before a short big blind ante",
+			ante:     2,
+			anteType: types.AnteBigBlind,
+			stacks:   []int64{100, 3, 100},
+			want:     []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2", "carol POST_ANTE 1"},
+		},
+		{
+			name:   "should skip the ante of a player all-in from a blind",
+			ante:   1,
+			stacks: []int64{1, 100, 1},
+			want: []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2",
+				"carol POST_ANTE 1", "alice POST_ANTE 1"},
+		},
+		{
+			name:     "should offer a UTG straddle after the antes",
+			ante:     1,
+			anteType: types.AnteBigBlind,
+			straddle: types.StraddleUTG,
+			stacks:   []int64{100, 100, 100},
+			want: []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2",
+				"carol POST_ANTE 1", "alice POST_STRADDLE 4 optional"},
+		},
+		{
+			name:     "should not offer a straddle the player cannot cover",
+			straddle: types.StraddleUTG,
+			stacks:   []int64{100, 100, 3},
+			want:     []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2"},
+		},
+		{
+			name:     "should not offer a button straddle on a dead button",
+			straddle: types.StraddleButton,
+			stacks:   []int64{100, 100, 100},
+			button:   "-",
+			want:     []string{"bob POST_SMALL_BLIND 1", "carol POST_BIG_BLIND 2"},
+		},
+		{
+			name:   "should skip a dead small blind",
+			stacks: []int64{100, 100, 100},
+			sb:     "-",
+			want:   []string{"carol POST_BIG_BLIND 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewBlindsManager(types.GameOptions{
+				SmallBlind: big.NewInt(1),
+				BigBlind:   big.NewInt(2),
+				Ante:       big.NewInt(tt.ante),
+				AnteType:   tt.anteType,
+				Straddle:   tt.straddle,
+			})
+
+			var players []types.IPlayer
+			for i, address := range []string{"bob", "carol", "alice"} {
+				players = append(players, models.NewPlayer(address, big.NewInt(tt.stacks[i]), i+2))
+			}
+			button, sb := "alice", "bob"
+			if tt.button == "-" {
+				button = ""
+			}
+			if tt.sb == "-" {
+				sb = ""
+			}
+
+			var turns []types.Turn
+			var got []string
+			for post := m.GetNextPost(players, button, sb, "carol", turns); post != nil; post = m.GetNextPost(players, button, sb, "carol", turns) {
+				entry := fmt.Sprintf("%s %s %s", post.Address, post.Action, post.Amount)
+				if post.Optional {
+					entry += " optional"
+				}
+				got = append(got, entry)
+				turns = append(turns, types.Turn{PlayerID: post.Address, Action: post.Action, Amount: post.Amount})
+				for _, p := range players {
+					if p.GetAddress() == post.Address {
+						p.SetChips(new(big.Int).Sub(p.GetChips(), post.Amount))
+					}
+				}
+				if len(got) > 10 {
+					t.Fatal("Expected the posts to end")
+				}
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestBlindsManager_BombPot tests every player posting a bomb pot ante
+func TestBlindsManager_BombPot(t *testing.T) {
+	m := NewBlindsManager(types.GameOptions{SmallBlind: big.NewInt(1), BigBlind: big.NewInt(2), Ante: big.NewInt(1)})
+
+	var players []types.IPlayer
+	for i, address := range []string{"bob", "carol", "alice"} {
+		players = append(players, models.NewPlayer(address, big.NewInt([]int64{100, 4, 100}[i]), i+2))
+	}
+
+	var turns []types.Turn
+	var got []string
+	for post := m.GetBombPotPost(players, big.NewInt(5), turns); post != nil; post = m.GetBombPotPost(players, big.NewInt(5), turns) {
+		got = append(got, fmt.Sprintf("%s %s %s", post.Address, post.Action, post.Amount))
+		turns = append(turns, types.Turn{PlayerID: post.Address, Action: post.Action, Amount: post.Amount})
+		if len(got) > 3 {
+			t.Fatal("Expected the posts to end")
+		}
+	}
+
+	want := []string{"bob POST_ANTE 5", "carol POST_ANTE 4", "alice POST_ANTE 5"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
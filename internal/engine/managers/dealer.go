@@ -0,0 +1,269 @@
+package managers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+var _ types.IDealerPositionManager = (*DealerPositionManager)(nil)
+
+// DealerPositionManager tracks the button and blinds using the dead button
+// rule: the big blind always moves to the next active player, the small
+// blind is posted by last hand's big blind, and the button sits behind the
+// small blind. When a blind's player leaves between hands the blind is dead
+// and the button may rest on an empty seat, so nobody misses or repeats a
+// big blind. Heads-up the button posts the small blind.
+type DealerPositionManager struct {
+	game       types.IDealer
+	dealer     int
+	smallBlind int
+	bigBlind   int
+}
+
+// NewDealerPositionManager creates a manager that has not placed the button.
+// Until the first hand starts the button is shown on the first active seat.
+func NewDealerPositionManager(game types.IDealer) *DealerPositionManager {
+	return &DealerPositionManager{game: game}
+}
+
+// HasButton reports whether the button has been placed for a hand
+func (m *DealerPositionManager) HasButton() bool {
+	return m.bigBlind != 0
+}
+
+// GetDealerPosition returns the button seat, which may be empty under the
+// dead button rule
+func (m *DealerPositionManager) GetDealerPosition() int {
+	dealer, _, _ := m.positions()
+	return dealer
+}
+
+// GetSmallBlindPosition returns the small blind seat. A dead small blind is
+// on a seat without an active player and is not posted.
+func (m *DealerPositionManager) GetSmallBlindPosition() int {
+	_, sb, _ := m.positions()
+	return sb
+}
+
+// GetBigBlindPosition returns the big blind seat
+func (m *DealerPositionManager) GetBigBlindPosition() int {
+	_, _, bb := m.positions()
+	return bb
+}
+
+// GetPosition returns a seat by position name: dealer (or button), small
+// blind, big blind or utg. It returns 0 for unknown names.
+func (m *DealerPositionManager) GetPosition(name string) int {
+	dealer, sb, bb := m.positions()
+	switch strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(name)) {
+	case "dealer", "button", "btn":
+		return dealer
+	case "smallblind", "sb":
+		return sb
+	case "bigblind", "bb":
+		return bb
+	case "utg", "underthegun":
+		if len(m.activeSeats()) == 2 {
+			return dealer
+		}
+		return m.nextActive(bb)
+	default:
+		return 0
+	}
+}
+
+// HandleNewHand moves the blinds and button for the next hand and returns
+// the new button seat
+func (m *DealerPositionManager) HandleNewHand() int {
+	active := m.activeSeats()
+	switch {
+	case len(active) < 2:
+		m.reset()
+		return 0
+	case m.bigBlind == 0:
+		m.dealer, m.smallBlind, m.bigBlind = m.fresh()
+		return m.dealer
+	}
+
+	bb := m.nextActive(m.bigBlind)
+	if len(active) == 2 {
+		m.headsUp(bb)
+		return m.dealer
+	}
+
+	// Last hand's big blind posts the small blind, dead if they have gone,
+	// and the button moves to last hand's small blind seat
+	m.dealer, m.smallBlind, m.bigBlind = m.smallBlind, m.bigBlind, bb
+	m.keepButtonBehindBlinds()
+	return m.dealer
+}
+
+// HandlePlayerLeave updates the positions for the next hand when a player
+// leaves or sits out between hands. A missing big blind moves on to the next
+// player; a missing small blind or button stays dead.
+func (m *DealerPositionManager) HandlePlayerLeave(seat int) {
+	if m.bigBlind == 0 {
+		return
+	}
+
+	active := m.activeSeats()
+	switch {
+	case len(active) < 2:
+		m.reset()
+	case len(active) == 2:
+		m.headsUp(m.bigBlind)
+	case seat == m.bigBlind:
+		m.bigBlind = m.nextActive(seat)
+		m.keepButtonBehindBlinds()
+	}
+}
+
+// HandlePlayerJoin updates the positions for the next hand when a player
+// joins or sits in between hands. A newcomer waits for the blinds to reach
+// them, unless the join ends heads-up play and the button must move off the
+// small blind.
+func (m *DealerPositionManager) HandlePlayerJoin(seat int) {
+	if m.bigBlind == 0 {
+		return
+	}
+
+	active := m.activeSeats()
+	switch {
+	case len(active) < 2:
+		return
+	case len(active) == 2:
+		m.headsUp(m.bigBlind)
+	case m.dealer == m.smallBlind:
+		// Leaving heads-up: the big blind stays and the button moves off the small blind
+		if !m.isActive(m.bigBlind) {
+			m.bigBlind = m.nextActive(m.bigBlind)
+		}
+		m.smallBlind = m.prevActive(m.bigBlind)
+		m.dealer = m.prevActive(m.smallBlind)
+	}
+}
+
+// ValidateDealerPosition reports whether the positions are usable: seats are
+// on the table, the big blind has an active player and, with three or more
+// players, the button and blinds are distinct
+func (m *DealerPositionManager) ValidateDealerPosition() bool {
+	dealer, sb, bb := m.positions()
+	max := m.game.GetMaxPlayers()
+	for _, seat := range []int{dealer, sb, bb} {
+		if seat < 1 || seat > max {
+			return false
+		}
+	}
+	if !m.isActive(bb) || sb == bb {
+		return false
+	}
+	if len(m.activeSeats()) == 2 {
+		return dealer == sb && m.isActive(sb)
+	}
+	return dealer != sb && dealer != bb
+}
+
+// FindNextActivePlayer returns the first active player after a seat
+func (m *DealerPositionManager) FindNextActivePlayer(currentSeat int) (types.IPlayer, error) {
+	seat := m.nextActive(currentSeat)
+	if seat == 0 {
+		return nil, fmt.Errorf("no active player after seat %d", currentSeat)
+	}
+	return m.game.GetPlayerAtSeat(seat)
+}
+
+// positions returns the stored positions, or fresh ones before the first hand
+func (m *DealerPositionManager) positions() (dealer, sb, bb int) {
+	if m.bigBlind == 0 {
+		return m.fresh()
+	}
+	return m.dealer, m.smallBlind, m.bigBlind
+}
+
+// fresh places the button on the first active seat with the blinds after it
+func (m *DealerPositionManager) fresh() (dealer, sb, bb int) {
+	active := m.activeSeats()
+	switch len(active) {
+	case 0:
+		return 0, 0, 0
+	case 1:
+		return active[0], 0, 0
+	case 2:
+		return active[0], active[0], active[1]
+	default:
+		return active[0], active[1], active[2]
+	}
+}
+
+// headsUp puts the button and small blind on the player who is not the big
+// blind, moving the big blind on if its seat is no longer active
+func (m *DealerPositionManager) headsUp(bb int) {
+	if !m.isActive(bb) {
+		bb = m.nextActive(bb)
+	}
+	m.bigBlind = bb
+	m.smallBlind = m.nextActive(bb)
+	m.dealer = m.smallBlind
+}
+
+// keepButtonBehindBlinds moves the button back behind the small blind if the
+// blinds have caught up with it
+func (m *DealerPositionManager) keepButtonBehindBlinds() {
+	if m.dealer == m.bigBlind || m.dealer == m.smallBlind {
+		m.dealer = m.prevActive(m.smallBlind)
+	}
+}
+
+// reset forgets the positions so the next hand starts fresh
+func (m *DealerPositionManager) reset() {
+	m.dealer, m.smallBlind, m.bigBlind = 0, 0, 0
+}
+
+// activeSeats returns the seats of players who will be dealt in, in order
+func (m *DealerPositionManager) activeSeats() []int {
+	var seats []int
+	for _, p := range m.game.FindActivePlayers() {
+		if seat := m.game.GetPlayerSeatNumber(p.GetAddress()); seat > 0 {
+			seats = append(seats, seat)
+		}
+	}
+	sort.Ints(seats)
+	return seats
+}
+
+// isActive reports whether an active player sits in a seat
+func (m *DealerPositionManager) isActive(seat int) bool {
+	for _, s := range m.activeSeats() {
+		if s == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// nextActive returns the first active seat after a seat, wrapping around
+// the table, or 0 if there is none
+func (m *DealerPositionManager) nextActive(seat int) int {
+	max := m.game.GetMaxPlayers()
+	for i := 1; i <= max; i++ {
+		if next := (seat+i-1)%max + 1; m.isActive(next) {
+			return next
+		}
+	}
+	return 0
+}
+
+// prevActive returns the first active seat before a seat, wrapping around
+// the table, or 0 if there is none
+func (m *DealerPositionManager) prevActive(seat int) int {
+	max := m.game.GetMaxPlayers()
+	for i := 1; i <= max; i++ {
+		if prev := ((seat-i-1)%max+max)%max + 1; m.isActive(prev) {
+			return prev
+		}
+	}
+	return 0
+}
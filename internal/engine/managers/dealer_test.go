@@ -0,0 +1,287 @@
+package managers
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// fakeDealer is a table where every seated player is active
+type fakeDealer struct {
+	max   int
+	seats map[int]bool
+}
+
+// newFakeDealer seats players in the given seats of a max-seat table
+func newFakeDealer(max int, seats ...int) *fakeDealer {
+	d := &fakeDealer{max: max, seats: make(map[int]bool)}
+	for _, seat := range seats {
+		d.seats[seat] = true
+	}
+	return d
+}
+
+func (d *fakeDealer) GetLastActedSeat() int  { return 0 }
+func (d *fakeDealer) GetDealerPosition() int { return 0 }
+func (d *fakeDealer) GetMinPlayers() int     { return 2 }
+func (d *fakeDealer) GetMaxPlayers() int     { return d.max }
+
+func (d *fakeDealer) FindActivePlayers() []types.IPlayer {
+	var seats []int
+	for seat := range d.seats {
+		seats = append(seats, seat)
+	}
+	sort.Ints(seats)
+
+	players := make([]types.IPlayer, len(seats))
+	for i, seat := range seats {
+		players[i] = models.NewPlayer(fmt.Sprintf("seat%d", seat), big.NewInt(100), seat)
+	}
+	return players
+}
+
+func (d *fakeDealer) GetPlayerAtSeat(seat int) (types.IPlayer, error) {
+	if !d.seats[seat] {
+		return nil, fmt.Errorf("no player in seat %d", seat)
+	}
+	return models.NewPlayer(fmt.Sprintf("seat%d", seat), big.NewInt(100), seat), nil
+}
+
+func (d *fakeDealer) GetPlayerSeatNumber(playerID string) int {
+	var seat int
+	if _, err := fmt.Sscanf(playerID, "seat%d", &seat); err != nil || !d.seats[seat] {
+		return 0
+	}
+	return seat
+}
+
+// expectPositions fails unless the button and blinds are in the given seats
+func expectPositions(t *testing.T, m *DealerPositionManager, dealer, sb, bb int) {
+	t.Helper()
+
+	if m.GetDealerPosition() != dealer || m.GetSmallBlindPosition() != sb || m.GetBigBlindPosition() != bb {
+		t.Errorf("Expected button %d and blinds %d/%d, got %d and %d/%d", dealer, sb, bb,
+			m.GetDealerPosition(), m.GetSmallBlindPosition(), m.GetBigBlindPosition())
+	}
+}
+
+// TestDealerPositionManager_Orbits tests every seat configuration of tables
+// of two to nine seats over a full orbit of the button
+func TestDealerPositionManager_Orbits(t *testing.T) {
+	type testCase struct {
+		max   int
+		seats []int
+	}
+
+	var tests []testCase
+	for max := 2; max <= 9; max++ {
+		for mask := 0; mask < 1<<max; mask++ {
+			var seats []int
+			for seat := 1; seat <= max; seat++ {
+				if mask&(1<<(seat-1)) != 0 {
+					seats = append(seats, seat)
+				}
+			}
+			if len(seats) >= 2 {
+				tests = append(tests, testCase{max, seats})
+			}
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-max %v", tt.max, tt.seats), func(t *testing.T) {
+			m := NewDealerPositionManager(newFakeDealer(tt.max, tt.seats...))
+			n := len(tt.seats)
+
+			if n == 2 {
+				expectPositions(t, m, tt.seats[0], tt.seats[0], tt.seats[1])
+			} else {
+				expectPositions(t, m, tt.seats[0], tt.seats[1], tt.seats[2])
+			}
+
+			bigBlinds := make(map[int]int)
+			m.HandleNewHand()
+			for hand := 0; hand < n; hand++ {
+				dealer, sb, bb := m.GetDealerPosition(), m.GetSmallBlindPosition(), m.GetBigBlindPosition()
+				if !m.ValidateDealerPosition() {
+					t.Fatalf("Hand %d: invalid positions %d %d/%d", hand+1, dealer, sb, bb)
+				}
+				bigBlinds[bb]++
+
+				m.HandleNewHand()
+				if next := m.nextActive(bb); m.GetBigBlindPosition() != next {
+					t.Fatalf("Hand %d: expected the big blind to move from %d to %d, got %d", hand+2, bb, next, m.GetBigBlindPosition())
+				}
+				if n == 2 {
+					if m.GetDealerPosition() != bb || m.GetSmallBlindPosition() != bb {
+						t.Fatalf("Hand %d: expected the heads-up button and small blind in %d", hand+2, bb)
+					}
+				} else if m.GetSmallBlindPosition() != bb || m.GetDealerPosition() != sb {
+					t.Fatalf("Hand %d: expected button %d and small blind %d, got %d and %d",
+						hand+2, sb, bb, m.GetDealerPosition(), m.GetSmallBlindPosition())
+				}
+			}
+
+			for _, seat := range tt.seats {
+				if bigBlinds[seat] != 1 {
+					t.Errorf("Expected seat %d to post one big blind per orbit, got %d", seat, bigBlinds[seat])
+				}
+			}
+		})
+	}
+}
+
+// TestDealerPositionManager_DeadButton tests players joining and leaving
+// between hands. Steps are "new" to move the button for the next hand,
+// "+n" or "-n" for a player joining or leaving seat n between hands, and
+// "~n" for a player leaving seat n during a hand.
+func TestDealerPositionManager_DeadButton(t *testing.T) {
+	tests := []struct {
+		name           string
+		seats          []int
+		steps          []string
+		dealer, sb, bb int
+		deadSmallBlind bool
+	}{
+		{
+			name:   "should move the blinds one seat each hand",
+			seats:  []int{1, 2, 3, 4},
+			steps:  []string{"new"},
+			dealer: 2, sb: 3, bb: 4,
+		},
+		{
+			name:   "should kill the small blind when last hand's big blind leaves",
+			seats:  []int{1, 2, 3, 4},
+			steps:  []string{"~3", "new"},
+			dealer: 2, sb: 3, bb: 4, deadSmallBlind: true,
+		},
+		{
+			name:   "should leave a dead button when last hand's small blind leaves",
+			seats:  []int{1, 2, 3, 4},
+			steps:  []string{"~2", "new"},
+			dealer: 2, sb: 3, bb: 4,
+		},
+		{
+			name:   "should move the button past a player who left from it",
+			seats:  []int{1, 2, 3, 4},
+			steps:  []string{"~1", "new"},
+			dealer: 2, sb: 3, bb: 4,
+		},
+		{
+			name:   "should move the big blind on when its player leaves before the hand",
+			seats:  []int{1, 2, 3, 4},
+			steps:  []string{"new", "-4"},
+			dealer: 2, sb: 3, bb: 1,
+		},
+		{
+			name:   "should kill the small blind when its player leaves before the hand",
+			seats:  []int{1, 2, 3, 4},
+			steps:  []string{"new", "-3"},
+			dealer: 2, sb: 3, bb: 4, deadSmallBlind: true,
+		},
+		{
+			name:   "should not give anyone two big blinds when going heads-up",
+			seats:  []int{1, 2, 3},
+			steps:  []string{"~2", "new"},
+			dealer: 3, sb: 3, bb: 1,
+		},
+		{
+			name:   "should move the button off the small blind when a third player joins",
+			seats:  []int{1, 2},
+			steps:  []string{"new", "+3"},
+			dealer: 2, sb: 3, bb: 1,
+		},
+		{
+			name:   "should rotate from heads-up to three-handed",
+			seats:  []int{1, 2},
+			steps:  []string{"+3", "new"},
+			dealer: 1, sb: 2, bb: 3,
+		},
+		{
+			name:   "should make a newcomer behind the big blind wait for it",
+			seats:  []int{1, 2, 4},
+			steps:  []string{"+3", "new"},
+			dealer: 2, sb: 4, bb: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newFakeDealer(9, tt.seats...)
+			m := NewDealerPositionManager(d)
+			m.HandleNewHand()
+
+			for _, step := range tt.steps {
+				if step == "new" {
+					m.HandleNewHand()
+					continue
+				}
+				seat, err := strconv.Atoi(step[1:])
+				if err != nil {
+					t.Fatalf("Bad step %q", step)
+				}
+				switch step[0] {
+				case '+':
+					d.seats[seat] = true
+					m.HandlePlayerJoin(seat)
+				case '-':
+					delete(d.seats, seat)
+					m.HandlePlayerLeave(seat)
+				case '~':
+					delete(d.seats, seat)
+				}
+			}
+
+			expectPositions(t, m, tt.dealer, tt.sb, tt.bb)
+			if dead := !d.seats[m.GetSmallBlindPosition()]; dead != tt.deadSmallBlind {
+				t.Errorf("Expected dead small blind %v, got %v", tt.deadSmallBlind, dead)
+			}
+			if !m.ValidateDealerPosition() {
+				t.Error("Expected valid positions")
+			}
+		})
+	}
+}
+
+// TestDealerPositionManager_Positions tests position lookups and resets
+func TestDealerPositionManager_Positions(t *testing.T) {
+	t.Run("should look up positions by name", func(t *testing.T) {
+		m := NewDealerPositionManager(newFakeDealer(9, 2, 4, 6, 8))
+		m.HandleNewHand()
+
+		for name, seat := range map[string]int{"dealer": 2, "button": 2, "small_blind": 4, "BB": 6, "utg": 8, "cutoff": 0} {
+			if got := m.GetPosition(name); got != seat {
+				t.Errorf("Expected %s in seat %d, got %d", name, seat, got)
+			}
+		}
+	})
+
+	t.Run("should find the next active player", func(t *testing.T) {
+		m := NewDealerPositionManager(newFakeDealer(6, 2, 5))
+
+		p, err := m.FindNextActivePlayer(5)
+		if err != nil || p.GetAddress() != "seat2" {
+			t.Errorf("Expected seat2, got %v, %v", p, err)
+		}
+		if _, err := NewDealerPositionManager(newFakeDealer(6)).FindNextActivePlayer(1); err == nil {
+			t.Error("Expected an error at an empty table")
+		}
+	})
+
+	t.Run("should forget the button when the table breaks up", func(t *testing.T) {
+		d := newFakeDealer(6, 1, 2)
+		m := NewDealerPositionManager(d)
+		m.HandleNewHand()
+
+		delete(d.seats, 2)
+		m.HandlePlayerLeave(2)
+		if m.HasButton() || m.ValidateDealerPosition() {
+			t.Error("Expected no button with one player")
+		}
+	})
+}
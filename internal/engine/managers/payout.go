@@ -0,0 +1,132 @@
+package managers
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/evaluator"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Contender is a player still holding cards when a pot is awarded
+type Contender struct {
+	Address string
+	High    evaluator.Hand
+	Low     *evaluator.LowHand // nil when the player has no qualifying low
+}
+
+// SplitAmount divides amount into n shares. Chips that do not divide evenly
+// are handed out one at a time from the first share onwards.
+func SplitAmount(amount *big.Int, n int) []*big.Int {
+	if n <= 0 {
+		return nil
+	}
+
+	share, remainder := new(big.Int).QuoRem(amount, big.NewInt(int64(n)), new(big.Int))
+	odd := int(remainder.Int64())
+
+	shares := make([]*big.Int, n)
+	for i := range shares {
+		shares[i] = new(big.Int).Set(share)
+		if i < odd {
+			shares[i].Add(shares[i], big.NewInt(1))
+		}
+	}
+	return shares
+}
+
+// AwardHigh splits a pot between the best high hands. Contenders must be
+// ordered from the first seat left of the button, which receives any odd chip.
+func AwardHigh(pot *big.Int, contenders []Contender) []types.Winner {
+	best := bestHigh(contenders)
+	if len(best) == 0 {
+		return nil
+	}
+
+	shares := SplitAmount(pot, len(best))
+	winners := make([]types.Winner, len(best))
+	for i, c := range best {
+		winners[i] = types.Winner{
+			Amount:      shares[i],
+			Cards:       c.High.Mnemonics(),
+			Name:        c.Address,
+			Description: c.High.Description(),
+		}
+	}
+	return winners
+}
+
+// AwardHiLo splits a pot in half between the best high hands and the best
+// qualifying lows, returning separate winners for each half. The high half
+// takes the odd chip, a player winning both halves appears twice and a tied
+// low is quartered. Without a qualifying low the high hands scoop the pot.
+func AwardHiLo(pot *big.Int, contenders []Contender) []types.Winner {
+	lows := bestLow(contenders)
+	if len(lows) == 0 {
+		return AwardHigh(pot, contenders)
+	}
+
+	halves := SplitAmount(pot, 2)
+	winners := AwardHigh(halves[0], contenders)
+	return append(winners, AwardLow(halves[1], contenders)...)
+}
+
+// AwardLow splits a pot between the best lows, as in lowball games where the
+// low takes the whole pot. Contenders without a low cannot win it and any odd
+// chip goes to the first winner in contender order.
+func AwardLow(pot *big.Int, contenders []Contender) []types.Winner {
+	lows := bestLow(contenders)
+	if len(lows) == 0 {
+		return nil
+	}
+
+	shares := SplitAmount(pot, len(lows))
+	winners := make([]types.Winner, len(lows))
+	for i, c := range lows {
+		winners[i] = types.Winner{
+			Amount:      shares[i],
+			Cards:       c.Low.Mnemonics(),
+			Name:        c.Address,
+			Description: c.Low.Description(),
+		}
+	}
+	return winners
+}
+
+// bestHigh returns the contenders tied for the best high hand, in order
+func bestHigh(contenders []Contender) []Contender {
+	var best []Contender
+	for _, c := range contenders {
+		if len(best) == 0 {
+			best = append(best, c)
+			continue
+		}
+		switch c.High.Compare(best[0].High) {
+		case 1:
+			best = append(best[:0], c)
+		case 0:
+			best = append(best, c)
+		}
+	}
+	return best
+}
+
+// bestLow returns the contenders tied for the best qualifying low, in order
+func bestLow(contenders []Contender) []Contender {
+	var best []Contender
+	for _, c := range contenders {
+		if c.Low == nil {
+			continue
+		}
+		if len(best) == 0 {
+			best = append(best, c)
+			continue
+		}
+		switch c.Low.Compare(*best[0].Low) {
+		case 1:
+			best = append(best[:0], c)
+		case 0:
+			best = append(best, c)
+		}
+	}
+	return best
+}
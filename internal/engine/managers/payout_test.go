@@ -0,0 +1,187 @@
+package managers
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/engine/evaluator"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// parseCards parses space separated mnemonics such as "AS KD 7C"
+func parseCards(t testing.TB, mnemonics string) []types.Card {
+	t.Helper()
+
+	fields := strings.Fields(mnemonics)
+	cards := make([]types.Card, len(fields))
+	for i, mnemonic := range fields {
+		card, err := models.FromString(mnemonic)
+		if err != nil {
+			t.Fatalf("FromString(%s) failed: %v", mnemonic, err)
+		}
+		cards[i] = card
+	}
+	return cards
+}
+
+// omahaContender evaluates an Omaha Hi-Lo hand against a board
+func omahaContender(t *testing.T, address, hole, board string) Contender {
+	t.Helper()
+
+	h, b := parseCards(t, hole), parseCards(t, board)
+	high, err := evaluator.EvaluateOmaha(h, b)
+	if err != nil {
+		t.Fatalf("EvaluateOmaha failed: %v", err)
+	}
+	c := Contender{Address: address, High: high}
+	if low, ok, err := evaluator.EvaluateOmahaLow(h, b); err != nil {
+		t.Fatalf("EvaluateOmahaLow failed: %v", err)
+	} else if ok {
+		c.Low = &low
+	}
+	return c
+}
+
+// totals sums winnings per address
+func totals(winners []types.Winner) map[string]int64 {
+	sums := make(map[string]int64)
+	for _, w := range winners {
+		sums[w.Name] += w.Amount.Int64()
+	}
+	return sums
+}
+
+// TestSplitAmount tests odd-chip allocation
+func TestSplitAmount(t *testing.T) {
+	shares := SplitAmount(big.NewInt(101), 3)
+
+	expected := []int64{34, 34, 33}
+	for i, share := range shares {
+		if share.Int64() != expected[i] {
+			t.Errorf("Share %d: expected %d, got %s", i, expected[i], share)
+		}
+	}
+}
+
+// TestAwardHigh tests pots awarded to the best high hand
+func TestAwardHigh(t *testing.T) {
+	board := "KC QD 7H 4S 2C"
+
+	t.Run("should award the whole pot to one winner", func(t *testing.T) {
+		winners := AwardHigh(big.NewInt(100), []Contender{
+			omahaContender(t, "alice", "AC AD 9S 8S", board),
+			omahaContender(t, "bob", "KH KS 9C 8C", board),
+		})
+
+		if len(winners) != 1 || winners[0].Name != "bob" || winners[0].Amount.Int64() != 100 {
+			t.Fatalf("Expected bob to win 100, got %+v", winners)
+		}
+		if winners[0].Description != "Three of a Kind, Kings" {
+			t.Errorf("Unexpected description %q", winners[0].Description)
+		}
+	})
+
+	t.Run("should give the odd chip to the first tied contender", func(t *testing.T) {
+		sums := totals(AwardHigh(big.NewInt(101), []Contender{
+			omahaContender(t, "alice", "AC AD 9S 8S", board),
+			omahaContender(t, "bob", "AH AS 9C 8C", board),
+		}))
+
+		if sums["alice"] != 51 || sums["bob"] != 50 {
+			t.Errorf("Expected 51/50, got %v", sums)
+		}
+	})
+}
+
+// TestAwardHiLo tests split pots between high and low hands
+func TestAwardHiLo(t *testing.T) {
+	t.Run("should scoop when no low qualifies", func(t *testing.T) {
+		winners := AwardHiLo(big.NewInt(100), []Contender{
+			omahaContender(t, "alice", "AC 2D KS QS", "KC QD 9H 4S TC"),
+			omahaContender(t, "bob", "JH 7C 3C 5D", "KC QD 9H 4S TC"),
+		})
+
+		if len(winners) != 1 || winners[0].Name != "alice" || winners[0].Amount.Int64() != 100 {
+			t.Errorf("Expected alice to scoop 100, got %+v", winners)
+		}
+	})
+
+	t.Run("should split high and low with separate winners", func(t *testing.T) {
+		winners := AwardHiLo(big.NewInt(101), []Contender{
+			omahaContender(t, "alice", "KH KS QC QS", "KC 7D 5H 3S 2C"),
+			omahaContender(t, "bob", "AC 8D JH JS", "KC 7D 5H 3S 2C"),
+		})
+
+		if len(winners) != 2 {
+			t.Fatalf("Expected two winners, got %+v", winners)
+		}
+		if winners[0].Name != "alice" || winners[0].Amount.Int64() != 51 {
+			t.Errorf("Expected alice to win 51 high, got %+v", winners[0])
+		}
+		if winners[1].Name != "bob" || winners[1].Amount.Int64() != 50 || winners[1].Description != "8-5-3-2-A Low" {
+			t.Errorf("Expected bob to win 50 with an eight low, got %+v", winners[1])
+		}
+	})
+
+	t.Run("should quarter a tied low", func(t *testing.T) {
+		board := "KC 7D 5H 3S 2C"
+		sums := totals(AwardHiLo(big.NewInt(100), []Contender{
+			omahaContender(t, "alice", "AD 4C 6C 4H", board),
+			omahaContender(t, "bob", "AH 4S JH JS", board),
+			omahaContender(t, "carol", "QH QS TD 9D", board),
+		}))
+
+		if sums["alice"] != 75 || sums["bob"] != 25 || sums["carol"] != 0 {
+			t.Errorf("Expected 75/25/0, got %v", sums)
+		}
+	})
+
+	t.Run("should list a player winning both halves twice", func(t *testing.T) {
+		winners := AwardHiLo(big.NewInt(100), []Contender{
+			omahaContender(t, "alice", "AD 4C 6H 8S", "KC 7D 5H 3S 2C"),
+			omahaContender(t, "bob", "QH QS JD JC", "KC 7D 5H 3S 2C"),
+		})
+
+		if len(winners) != 2 || winners[0].Name != "alice" || winners[1].Name != "alice" {
+			t.Errorf("Expected alice to win both halves, got %+v", winners)
+		}
+	})
+}
+
+// deuceToSevenContender evaluates a five-card 2-7 lowball hand
+func deuceToSevenContender(t *testing.T, address, hand string) Contender {
+	t.Helper()
+
+	low, err := evaluator.EvaluateDeuceToSeven(parseCards(t, hand))
+	if err != nil {
+		t.Fatalf("EvaluateDeuceToSeven failed: %v", err)
+	}
+	return Contender{Address: address, Low: &low}
+}
+
+// TestAwardLow tests pots awarded to the best low alone
+func TestAwardLow(t *testing.T) {
+	t.Run("should award the whole pot to the best low", func(t *testing.T) {
+		winners := AwardLow(big.NewInt(100), []Contender{
+			deuceToSevenContender(t, "alice", "8C 6D 4H 3S 2C"),
+			deuceToSevenContender(t, "bob", "7D 6H 5S 3C 2D"),
+		})
+
+		if len(winners) != 1 || winners[0].Name != "bob" || winners[0].Amount.Int64() != 100 {
+			t.Errorf("Expected bob to win 100, got %+v", winners)
+		}
+	})
+
+	t.Run("should split a tied low with the odd chip first", func(t *testing.T) {
+		sums := totals(AwardLow(big.NewInt(101), []Contender{
+			deuceToSevenContender(t, "alice", "7C 5D 4H 3S 2C"),
+			deuceToSevenContender(t, "bob", "7D 5H 4S 3C 2D"),
+		}))
+
+		if sums["alice"] != 51 || sums["bob"] != 50 {
+			t.Errorf("Expected 51/50, got %v", sums)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package managers
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// PotManager splits the chips bet in a hand into the main pot and side pots.
+// Each pot is capped at what its shortest eligible stack put in, so an
+// all-in player can only win from each other player what they risked.
+type PotManager struct {
+	contenders    []string            // Players still in the hand, in payout order
+	contributions map[string]*big.Int // Chips each player has put in this hand
+	uncalled      string              // Player whose last bet nobody matched
+	uncalledBet   *big.Int
+}
+
+// NewPotManager builds the pots from per-round bets, as returned by
+// GetBets. Contenders are the players who have not folded, ordered from the
+// first seat left of the button; anyone else who bet has folded.
+func NewPotManager(contenders []string, rounds ...map[string]*big.Int) *PotManager {
+	m := &PotManager{
+		contenders:    contenders,
+		contributions: make(map[string]*big.Int),
+		uncalledBet:   big.NewInt(0),
+	}
+	for _, bets := range rounds {
+		for address, amount := range bets {
+			if _, ok := m.contributions[address]; !ok {
+				m.contributions[address] = big.NewInt(0)
+			}
+			m.contributions[address].Add(m.contributions[address], amount)
+		}
+	}
+
+	// The part of the largest bet that nobody matched goes back to the bettor
+	var largest, second *big.Int
+	for address, amount := range m.contributions {
+		switch {
+		case largest == nil || amount.Cmp(largest) > 0:
+			second, largest = largest, amount
+			m.uncalled = address
+		case second == nil || amount.Cmp(second) > 0:
+			second = amount
+		}
+	}
+	if largest != nil && m.isContender(m.uncalled) {
+		if second == nil {
+			second = big.NewInt(0)
+		}
+		m.uncalledBet.Sub(largest, second)
+	}
+	if m.uncalledBet.Sign() == 0 {
+		m.uncalled = ""
+	}
+	return m
+}
+
+// GetContribution returns the chips a player has put in this hand,
+// including any uncalled bet
+func (m *PotManager) GetContribution(address string) *big.Int {
+	if amount, ok := m.contributions[address]; ok {
+		return new(big.Int).Set(amount)
+	}
+	return big.NewInt(0)
+}
+
+// GetUncalledBet returns the player whose bet nobody matched and the amount
+// to return to them, or an empty address and zero
+func (m *PotManager) GetUncalledBet() (string, *big.Int) {
+	return m.uncalled, new(big.Int).Set(m.uncalledBet)
+}
+
+// GetTotal returns the chips in all pots, excluding any uncalled bet
+func (m *PotManager) GetTotal() *big.Int {
+	total := big.NewInt(0)
+	for _, amount := range m.contributions {
+		total.Add(total, amount)
+	}
+	return total.Sub(total, m.uncalledBet)
+}
+
+// GetPots returns the main pot followed by the side pots in the order they
+// were created. Eligible players keep the contender order. Chips from folded
+// players beyond every contender's stake stay in the last pot.
+func (m *PotManager) GetPots() []types.Pot {
+	remaining := make(map[string]*big.Int, len(m.contributions))
+	for address, amount := range m.contributions {
+		remaining[address] = new(big.Int).Set(amount)
+	}
+	if m.uncalled != "" {
+		remaining[m.uncalled].Sub(remaining[m.uncalled], m.uncalledBet)
+	}
+
+	var pots []types.Pot
+	for {
+		// Each pot is capped by the smallest stake among those still in it
+		var level *big.Int
+		for _, address := range m.contenders {
+			if amount, ok := remaining[address]; ok && amount.Sign() > 0 && (level == nil || amount.Cmp(level) < 0) {
+				level = amount
+			}
+		}
+		if level == nil {
+			break
+		}
+		level = new(big.Int).Set(level)
+
+		var eligible []string
+		for _, address := range m.contenders {
+			if amount, ok := remaining[address]; ok && amount.Cmp(level) >= 0 {
+				eligible = append(eligible, address)
+			}
+		}
+
+		pot := types.Pot{Amount: big.NewInt(0), Eligible: eligible}
+		for _, amount := range remaining {
+			take := level
+			if amount.Cmp(level) < 0 {
+				take = amount
+			}
+			pot.Amount.Add(pot.Amount, take)
+			amount.Sub(amount, take)
+		}
+
+		if n := len(pots); n > 0 && sameEligible(pots[n-1].Eligible, eligible) {
+			pots[n-1].Amount.Add(pots[n-1].Amount, pot.Amount)
+		} else {
+			pots = append(pots, pot)
+		}
+	}
+
+	leftover := big.NewInt(0)
+	for _, amount := range remaining {
+		leftover.Add(leftover, amount)
+	}
+	if leftover.Sign() > 0 {
+		if len(pots) == 0 {
+			pots = append(pots, types.Pot{Amount: big.NewInt(0)})
+		}
+		pots[len(pots)-1].Amount.Add(pots[len(pots)-1].Amount, leftover)
+	}
+	return pots
+}
+
+// isContender reports whether a player can still win chips this hand
+func (m *PotManager) isContender(address string) bool {
+	for _, contender := range m.contenders {
+		if contender == address {
+			return true
+		}
+	}
+	return false
+}
+
+// sameEligible reports whether two pots can be won by the same players
+func sameEligible(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package managers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// bets builds one round of bets from address and amount pairs
+func bets(pairs ...interface{}) map[string]*big.Int {
+	round := make(map[string]*big.Int)
+	for i := 0; i < len(pairs); i += 2 {
+		round[pairs[i].(string)] = big.NewInt(int64(pairs[i+1].(int)))
+	}
+	return round
+}
+
+// expectPots fails unless the pots have the given amounts and eligible players
+func expectPots(t *testing.T, got []types.Pot, want []types.Pot) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d pots, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i].Amount.Cmp(want[i].Amount) != 0 || !sameEligible(got[i].Eligible, want[i].Eligible) {
+			t.Errorf("Pot %d: expected %s for %v, got %s for %v", i, want[i].Amount, want[i].Eligible, got[i].Amount, got[i].Eligible)
+		}
+	}
+}
+
+// TestPotManager tests main and side pot construction
+func TestPotManager(t *testing.T) {
+	t.Run("should build a single pot when every bet is matched", func(t *testing.T) {
+		m := NewPotManager([]string{"alice", "bob"}, bets("alice", 2, "bob", 2), bets("alice", 10, "bob", 10))
+
+		expectPots(t, m.GetPots(), []types.Pot{{Amount: big.NewInt(24), Eligible: []string{"alice", "bob"}}})
+		if address, amount := m.GetUncalledBet(); address != "" || amount.Sign() != 0 {
+			t.Errorf("Expected no uncalled bet, got %s %s", address, amount)
+		}
+	})
+
+	t.Run("should build side pots for different all-in amounts", func(t *testing.T) {
+		m := NewPotManager([]string{"alice", "bob", "carol"},
+			bets("alice", 50, "bob", 50, "carol", 50, "dave", 30),
+			bets("bob", 50, "carol", 150),
+		)
+
+		expectPots(t, m.GetPots(), []types.Pot{
+			{Amount: big.NewInt(180), Eligible: []string{"alice", "bob", "carol"}},
+			{Amount: big.NewInt(100), Eligible: []string{"bob", "carol"}},
+		})
+		if address, amount := m.GetUncalledBet(); address != "carol" || amount.Int64() != 100 {
+			t.Errorf("Expected 100 uncalled from carol, got %s %s", address, amount)
+		}
+		if m.GetTotal().Int64() != 280 || m.GetContribution("carol").Int64() != 200 {
+			t.Errorf("Expected a total of 280 with 200 from carol, got %s and %s", m.GetTotal(), m.GetContribution("carol"))
+		}
+	})
+
+	t.Run("should keep folded chips above every stake in the last pot", func(t *testing.T) {
+		m := NewPotManager([]string{"alice", "bob"}, bets("alice", 40, "bob", 40, "carol", 60))
+
+		expectPots(t, m.GetPots(), []types.Pot{{Amount: big.NewInt(140), Eligible: []string{"alice", "bob"}}})
+		if _, amount := m.GetUncalledBet(); amount.Sign() != 0 {
+			t.Errorf("Expected no uncalled bet from a folded player, got %s", amount)
+		}
+	})
+
+	t.Run("should merge pots with the same eligible players", func(t *testing.T) {
+		m := NewPotManager([]string{"bob", "carol"}, bets("alice", 20, "bob", 50, "carol", 50))
+
+		expectPots(t, m.GetPots(), []types.Pot{{Amount: big.NewInt(120), Eligible: []string{"bob", "carol"}}})
+	})
+}
@@ -0,0 +1,77 @@
+package managers
+
+import (
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// basisPoints is the number of basis points in a whole
+var basisPoints = big.NewInt(10000)
+
+// RakeManager works out the house rake for a hand. Rake is taken from each
+// pot in turn, main pot first, rounded down to whole chips and limited by
+// the hand's cap.
+type RakeManager struct {
+	options types.RakeOptions
+}
+
+// NewRakeManager creates a rake manager. A nil options takes no rake.
+func NewRakeManager(options *types.RakeOptions) *RakeManager {
+	m := &RakeManager{}
+	if options != nil {
+		m.options = *options
+	}
+	return m
+}
+
+// Enabled reports whether the table takes any rake
+func (m *RakeManager) Enabled() bool {
+	return m.options.BasisPoints > 0
+}
+
+// GetCap returns the most rake that can be taken from a hand dealt to a
+// number of players, or nil if there is no cap. A cap for the largest player
+// count not above players overrides the table cap.
+func (m *RakeManager) GetCap(players int) *big.Int {
+	best := 0
+	var limit *big.Int
+	for count, amount := range m.options.CapsByPlayers {
+		if count <= players && count > best && amount != nil {
+			best, limit = count, amount
+		}
+	}
+	if limit == nil {
+		limit = m.options.Cap
+	}
+	if limit == nil {
+		return nil
+	}
+	return new(big.Int).Set(limit)
+}
+
+// Take returns the rake from each pot of a hand, given how many players were
+// dealt in and whether the hand saw a flop
+func (m *RakeManager) Take(pots []types.Pot, players int, flop bool) []*big.Int {
+	rake := make([]*big.Int, len(pots))
+	for i := range rake {
+		rake[i] = big.NewInt(0)
+	}
+	if !m.Enabled() || (m.options.NoFlopNoDrop && !flop) {
+		return rake
+	}
+
+	remaining := m.GetCap(players)
+	for i, pot := range pots {
+		amount := new(big.Int).Mul(pot.Amount, big.NewInt(int64(m.options.BasisPoints)))
+		amount.Quo(amount, basisPoints)
+		if remaining != nil {
+			if amount.Cmp(remaining) > 0 {
+				amount.Set(remaining)
+			}
+			remaining.Sub(remaining, amount)
+		}
+		rake[i] = amount
+	}
+	return rake
+}
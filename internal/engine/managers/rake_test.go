@@ -0,0 +1,92 @@
+package managers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// pots builds pots with the given amounts
+func pots(amounts ...int64) []types.Pot {
+	result := make([]types.Pot, len(amounts))
+	for i, amount := range amounts {
+		result[i] = types.Pot{Amount: big.NewInt(amount)}
+	}
+	return result
+}
+
+// TestRakeManager tests rake rates, caps and no flop, no drop
+func TestRakeManager(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *types.RakeOptions
+		pots    []types.Pot
+		players int
+		flop    bool
+		want    []int64
+	}{
+		{
+			name:    "should take no rake without options",
+			pots:    pots(100),
+			players: 2, flop: true,
+			want: []int64{0},
+		},
+		{
+			name:    "should round the rake down to whole chips",
+			options: &types.RakeOptions{BasisPoints: 500},
+			pots:    pots(59),
+			players: 2, flop: true,
+			want: []int64{2},
+		},
+		{
+			name:    "should take no rake before the flop",
+			options: &types.RakeOptions{BasisPoints: 500, NoFlopNoDrop: true},
+			pots:    pots(100),
+			players: 6, flop: false,
+			want: []int64{0},
+		},
+		{
+			name:    "should rake each side pot until the cap is reached",
+			options: &types.RakeOptions{BasisPoints: 1000, Cap: big.NewInt(15)},
+			pots:    pots(100, 80, 40),
+			players: 4, flop: true,
+			want: []int64{10, 5, 0},
+		},
+		{
+			name: "should use the cap for the player count",
+			options: &types.RakeOptions{
+				BasisPoints:   1000,
+				Cap:           big.NewInt(30),
+				CapsByPlayers: map[int]*big.Int{2: big.NewInt(5), 4: big.NewInt(10)},
+			},
+			pots:    pots(200),
+			players: 3, flop: true,
+			want: []int64{5},
+		},
+		{
+			name: "should use the largest player count not above the players dealt in",
+			options: &types.RakeOptions{
+				BasisPoints:   1000,
+				CapsByPlayers: map[int]*big.Int{2: big.NewInt(5), 4: big.NewInt(10)},
+			},
+			pots:    pots(200),
+			players: 9, flop: true,
+			want: []int64{10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rake := NewRakeManager(tt.options).Take(tt.pots, tt.players, tt.flop)
+			if len(rake) != len(tt.want) {
+				t.Fatalf("Expected %d amounts, got %d", len(tt.want), len(rake))
+			}
+			for i, want := range tt.want {
+				if rake[i].Int64() != want {
+					t.Errorf("Pot %d: expected rake %d, got %s", i, want, rake[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package stud
+
+import (
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// upCards returns the cards a player shows face up: the third to sixth cards
+// dealt. The first two and the seventh are dealt face down.
+func upCards(p *models.Player) []types.Card {
+	if len(p.HoleCards) <= 2 {
+		return nil
+	}
+	end := len(p.HoleCards)
+	if end > 6 {
+		end = 6
+	}
+	return p.HoleCards[2:end]
+}
+
+// aceHigh returns a card's rank with aces above kings
+func aceHigh(rank int) int {
+	if rank == 1 {
+		return 14
+	}
+	return rank
+}
+
+// lowerCard reports whether a is a lower up-card than b for the bring-in.
+// Aces are high and equal ranks are broken by suit in types.Suit order, clubs
+// lowest.
+func lowerCard(a, b types.Card) bool {
+	if ra, rb := aceHigh(a.Rank), aceHigh(b.Rank); ra != rb {
+		return ra < rb
+	}
+	return a.Suit < b.Suit
+}
+
+// bringInSeat returns the seat of the player with chips showing the lowest
+// up-card on third street, or 0 if nobody can bring in
+func (g *SevenCardStud) bringInSeat() int {
+	best := 0
+	var lowest types.Card
+	for _, seat := range g.SeatsFrom(0, g.CanAct) {
+		up := upCards(g.Players[seat])
+		if len(up) == 0 {
+			continue
+		}
+		if best == 0 || lowerCard(up[0], lowest) {
+			best, lowest = seat, up[0]
+		}
+	}
+	return best
+}
+
+// upScore ranks a player's up cards to decide who acts first: quads, trips,
+// two pair, a pair, then high cards, with ranks breaking ties. Straights and
+// flushes do not count. Scores compare element by element.
+func upScore(cards []types.Card) []int {
+	var counts [15]int
+	for _, card := range cards {
+		counts[aceHigh(card.Rank)]++
+	}
+
+	// Group sizes come first so two pair beats any single pair
+	var sizes, ranks []int
+	for size := 4; size >= 1; size-- {
+		for rank := 14; rank >= 2; rank-- {
+			if counts[rank] == size {
+				sizes = append(sizes, size)
+				ranks = append(ranks, rank)
+			}
+		}
+	}
+	return append(sizes, ranks...)
+}
+
+// compareScores compares two up-card scores, returning 1 if a is better, -1
+// if b is better and 0 if they are equal
+func compareScores(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i] > b[i]:
+			return 1
+		case a[i] < b[i]:
+			return -1
+		}
+	}
+	return 0
+}
+
+// bestShowingSeat returns the seat of the player still in the hand with the
+// best up cards. Equal hands go to the lowest seat.
+func (g *SevenCardStud) bestShowingSeat() int {
+	best := 0
+	var score []int
+	for _, seat := range g.SeatsFrom(0, g.Contesting) {
+		s := upScore(upCards(g.Players[seat]))
+		if best == 0 || compareScores(s, score) > 0 {
+			best, score = seat, s
+		}
+	}
+	return best
+}
@@ -0,0 +1,199 @@
+package stud
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/engine/evaluator"
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// PerformAction applies a player's action. The index must equal
+// GetActionIndex and the amount is the number of chips the action moves from
+// the player's stack, within the range given by GetLegalActions.
+func (g *SevenCardStud) PerformAction(address string, action types.PlayerActionType, index int, amount *big.Int) error {
+	player, err := g.GetPlayer(address)
+	if err != nil {
+		return err
+	}
+	a, err := actions.New(g, action)
+	if err != nil {
+		return err
+	}
+	if err := a.Execute(player, index, amount); err != nil {
+		return err
+	}
+	return g.advance()
+}
+
+// Deal deals third street once the antes are posted: two cards down and one
+// up to each player. It does nothing at any other time.
+func (g *SevenCardStud) Deal() {
+	if !g.CanDeal() {
+		return
+	}
+	if !g.Started() {
+		g.StartHand()
+	}
+	if err := g.dealStreet(); err != nil {
+		return
+	}
+
+	// The antes may have put everyone all-in
+	_ = g.advance()
+}
+
+// advance moves the hand on through any rounds that have ended, dealing the
+// next street and awarding the pot as needed
+func (g *SevenCardStud) advance() error {
+	for {
+		switch g.Round {
+		case types.RoundThirdStreet, types.RoundFourthStreet, types.RoundFifthStreet,
+			types.RoundSixthStreet, types.RoundSeventhStreet:
+			if g.Started() && g.Dealt() && g.CountPlayers(g.Contesting) == 1 {
+				g.AwardUncontested()
+				return nil
+			}
+			if !g.HasRoundEnded(g.Round) {
+				return nil
+			}
+			if err := g.nextStreet(); err != nil {
+				return err
+			}
+		case types.RoundShowdown:
+			if !g.HasRoundEnded(g.Round) {
+				return nil
+			}
+			if g.CountPlayers(g.Contesting) == 1 {
+				g.AwardUncontested()
+				return nil
+			}
+			return g.showdown()
+		default:
+			return nil
+		}
+	}
+}
+
+// nextStreet moves to the next round and deals its street
+func (g *SevenCardStud) nextStreet() error {
+	g.Round = rounds[g.RoundOrder(g.Round)+1]
+	if g.Round == types.RoundShowdown {
+		return nil
+	}
+	return g.dealStreet()
+}
+
+// dealStreet burns a card and deals the current street one card at a time in
+// seat order: three cards on third street and one after. The burn is skipped
+// when the deck could not otherwise finish the hand, and if there are too
+// few cards for everyone on seventh street a single shared card is dealt
+// face up instead.
+func (g *SevenCardStud) dealStreet() error {
+	seats := g.SeatsFrom(0, g.Contesting)
+	count := 1
+	if g.Round == types.RoundThirdStreet {
+		count = 3
+	}
+
+	shared := g.Round == types.RoundSeventhStreet && g.Deck.Remaining() < len(seats)
+	needed := g.cardsNeeded(len(seats))
+	if shared {
+		needed = 1
+	}
+	if g.Deck.Remaining() > needed {
+		if _, err := g.Deck.Burn(); err != nil {
+			return err
+		}
+	}
+
+	if shared {
+		cards, err := g.Deck.DealBoard(1)
+		if err != nil {
+			return err
+		}
+		g.board = append(g.board, cards...)
+		return nil
+	}
+	for i := 0; i < count; i++ {
+		for _, seat := range seats {
+			cards, err := g.Deck.DealHole(seat, 1)
+			if err != nil {
+				return err
+			}
+			g.Players[seat].HoleCards = append(g.Players[seat].HoleCards, cards...)
+		}
+	}
+	return nil
+}
+
+// cardsNeeded returns the fewest cards that finish the hand for a number of
+// players from the current street on, counting seventh street as a single
+// shared card unless it is the street being dealt
+func (g *SevenCardStud) cardsNeeded(players int) int {
+	if g.Round == types.RoundSeventhStreet {
+		return players
+	}
+	needed := 1
+	for i := g.RoundOrder(g.Round); rounds[i] != types.RoundSeventhStreet; i++ {
+		if rounds[i] == types.RoundThirdStreet {
+			needed += 3 * players
+		} else {
+			needed += players
+		}
+	}
+	return needed
+}
+
+// showdown evaluates the best five of each player's seven cards, with any
+// shared card, and pays each pot, less rake, to the best hands eligible for
+// it. Odd chips go to the first winner in seat order. Winners are listed pot
+// by pot, starting with the main pot.
+func (g *SevenCardStud) showdown() error {
+	var contenders []managers.Contender
+	for _, seat := range g.SeatsFrom(0, g.Contesting) {
+		p := g.Players[seat]
+		high, err := evaluator.EvaluateHoldem(p.HoleCards, g.board)
+		if err != nil {
+			return fmt.Errorf("evaluating seat %d: %w", seat, err)
+		}
+		contenders = append(contenders, managers.Contender{Address: p.Address, High: high})
+	}
+
+	manager := g.PotManager()
+	g.ReturnUncalled(manager)
+
+	g.Winners = nil
+	for _, pot := range g.TakeRake(manager.GetPots()) {
+		eligible := make([]managers.Contender, 0, len(pot.Eligible))
+		for _, c := range contenders {
+			for _, address := range pot.Eligible {
+				if c.Address == address {
+					eligible = append(eligible, c)
+				}
+			}
+		}
+		g.Winners = append(g.Winners, managers.AwardHigh(pot.Amount, eligible)...)
+	}
+	for _, winner := range g.Winners {
+		p, err := g.GetPlayer(winner.Name)
+		if err != nil {
+			return err
+		}
+		p.Chips = new(big.Int).Add(p.Chips, winner.Amount)
+	}
+
+	g.Round = types.RoundEnd
+	return nil
+}
+
+// ReInit starts the next hand with a fresh deck once the current hand is over
+func (g *SevenCardStud) ReInit(deck string) error {
+	if err := g.NextHand(deck); err != nil {
+		return err
+	}
+	g.board = nil
+	return nil
+}
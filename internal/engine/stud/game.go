@@ -0,0 +1,300 @@
+package stud
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/block52/go-pvm/internal/engine/actions"
+	"github.com/block52/go-pvm/internal/engine/base"
+	"github.com/block52/go-pvm/internal/engine/managers"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by the game engine
+var (
+	ErrInvalidOptions = base.ErrInvalidOptions
+	ErrInvalidSeat    = base.ErrInvalidSeat
+	ErrSeatTaken      = base.ErrSeatTaken
+	ErrAlreadySeated  = base.ErrAlreadySeated
+	ErrPlayerNotFound = base.ErrPlayerNotFound
+	ErrNotYourTurn    = actions.ErrNotYourTurn
+	ErrInvalidIndex   = actions.ErrInvalidIndex
+	ErrIllegalAction  = actions.ErrIllegalAction
+	ErrInvalidAmount  = actions.ErrInvalidAmount
+	ErrHandInProgress = base.ErrHandInProgress
+	ErrNoPlayerToAct  = base.ErrNoPlayerToAct
+	ErrNoActions      = base.ErrNoActions
+)
+
+var _ types.IPoker = (*SevenCardStud)(nil)
+
+// maxPlayers is the most players a stud table seats. With eight players the
+// deck still finishes the hand using a shared seventh street card.
+const maxPlayers = 8
+
+// rounds lists the rounds of a hand in the order they are played
+var rounds = []types.TexasHoldemRound{
+	types.RoundThirdStreet,
+	types.RoundFourthStreet,
+	types.RoundFifthStreet,
+	types.RoundSixthStreet,
+	types.RoundSeventhStreet,
+	types.RoundShowdown,
+	types.RoundEnd,
+}
+
+// SevenCardStud is a seven card stud table implementing types.IPoker.
+//
+// A hand starts on third street with the antes posted as turns, then Deal
+// gives every player two cards down and one up. The lowest up-card brings
+// in and betting begins. Fourth to sixth street are dealt face up and
+// seventh street face down, with the best hand showing acting first on each
+// street after third. The remaining players show or muck before the pot is
+// awarded and the round moves to END. ReInit starts the next hand.
+//
+// Stud is played fixed-limit: the small bet on third and fourth street and
+// the big bet after. There is no button and no blinds, so the bring-in
+// stands in for the small blind and the small bet for the big blind.
+type SevenCardStud struct {
+	*base.Game
+	antes *managers.BlindsManager
+	board []types.Card // A shared seventh street card when the deck runs short
+}
+
+// NewSevenCardStud creates an empty table that will deal its first hand from deck
+func NewSevenCardStud(options types.GameOptions, deck string) (*SevenCardStud, error) {
+	if options.MaxPlayers > maxPlayers {
+		return nil, fmt.Errorf("%w: at most %d players, got max %d", ErrInvalidOptions, maxPlayers, options.MaxPlayers)
+	}
+	if options.Variant == "" {
+		options.Variant = types.VariantSevenCardStud
+	}
+	if options.Variant != types.VariantSevenCardStud {
+		return nil, fmt.Errorf("%w: %s is not a stud variant", ErrInvalidOptions, options.Variant)
+	}
+	if options.Betting == "" {
+		options.Betting = types.BettingFixedLimit
+	}
+	if options.Betting != types.BettingFixedLimit {
+		return nil, fmt.Errorf("%w: stud is only dealt fixed-limit", ErrInvalidOptions)
+	}
+	if options.Straddle != types.StraddleNone || (options.AnteType != "" && options.AnteType != types.AnteClassic) {
+		return nil, fmt.Errorf("%w: stud has no straddles and every player antes", ErrInvalidOptions)
+	}
+	if options.BringIn == nil || options.SmallBet == nil ||
+		options.BringIn.Sign() <= 0 || options.SmallBet.Cmp(options.BringIn) < 0 {
+		return nil, fmt.Errorf("%w: bring-in must be positive and at most the small bet", ErrInvalidOptions)
+	}
+	options.SmallBlind = new(big.Int).Set(options.BringIn)
+	options.BigBlind = new(big.Int).Set(options.SmallBet)
+
+	// Buy-ins default to between 10 and 50 big bets
+	if options.BigBet == nil {
+		options.BigBet = new(big.Int).Mul(options.SmallBet, big.NewInt(2))
+	}
+	if options.MinBuyIn == nil {
+		options.MinBuyIn = new(big.Int).Mul(options.BigBet, big.NewInt(10))
+	}
+	if options.MaxBuyIn == nil {
+		options.MaxBuyIn = new(big.Int).Mul(options.BigBet, big.NewInt(50))
+	}
+	if err := base.CheckOptions(&options); err != nil {
+		return nil, err
+	}
+
+	g := &SevenCardStud{antes: managers.NewBlindsManager(options)}
+	game, err := base.New(g, options, deck, rounds)
+	if err != nil {
+		return nil, err
+	}
+	g.Game = game
+	return g, nil
+}
+
+// GetFixedBet returns the size of a bet or raise in the current round: the
+// small bet on third and fourth street, the big bet after
+func (g *SevenCardStud) GetFixedBet() *big.Int {
+	switch g.Round {
+	case types.RoundThirdStreet, types.RoundFourthStreet:
+		return new(big.Int).Set(g.Options.SmallBet)
+	default:
+		return new(big.Int).Set(g.Options.BigBet)
+	}
+}
+
+// GetMaxRuns returns 0. Stud boards are never run more than once.
+func (g *SevenCardStud) GetMaxRuns() int {
+	return 0
+}
+
+// GetCommunityCards returns the shared seventh street card, if the deck ran
+// too short to deal one to each player, or no cards
+func (g *SevenCardStud) GetCommunityCards() []types.Card {
+	return append([]types.Card(nil), g.board...)
+}
+
+// GetUpCards returns the cards a player shows face up
+func (g *SevenCardStud) GetUpCards(address string) ([]types.Card, error) {
+	p, err := g.GetPlayer(address)
+	if err != nil {
+		return nil, err
+	}
+	return append([]types.Card(nil), upCards(p)...), nil
+}
+
+// GetNextPost returns the ante or bring-in due next this hand, or nil once
+// every forced bet is in or the hand cannot start. Antes are posted before
+// the deal, starting from seat 1, and the bring-in after it.
+func (g *SevenCardStud) GetNextPost() *managers.Post {
+	if g.Round != types.RoundThirdStreet || g.CountPlayers(g.InHand) < g.Options.MinPlayers {
+		return nil
+	}
+
+	if !g.Dealt() {
+		var players []types.IPlayer
+		for _, seat := range g.SeatsFrom(0, g.InHand) {
+			players = append(players, g.Players[seat])
+		}
+		return g.antes.GetNextPost(players, "", "", "", g.GetTurns(types.RoundThirdStreet))
+	}
+
+	if g.HasPosted(types.ActionBringIn) {
+		return nil
+	}
+	seat := g.bringInSeat()
+	if seat == 0 {
+		return nil
+	}
+	p := g.Players[seat]
+	amount := new(big.Int).Set(g.Options.BringIn)
+	if p.Chips.Cmp(amount) < 0 {
+		amount.Set(p.Chips)
+	}
+	return &managers.Post{Address: p.Address, Action: types.ActionBringIn, Amount: amount}
+}
+
+// GetNextPlayerToAct returns the player whose turn it is
+func (g *SevenCardStud) GetNextPlayerToAct() (types.IPlayer, error) {
+	seat, err := g.nextToActSeat()
+	if err != nil {
+		return nil, err
+	}
+	return g.Players[seat], nil
+}
+
+// nextToActSeat finds the seat of the player whose turn it is
+func (g *SevenCardStud) nextToActSeat() (int, error) {
+	switch g.Round {
+	case types.RoundThirdStreet:
+		if g.CountPlayers(g.InHand) < g.Options.MinPlayers {
+			return 0, fmt.Errorf("%w: waiting for %d players", ErrNoPlayerToAct, g.Options.MinPlayers)
+		}
+		if post := g.GetNextPost(); post != nil {
+			return g.GetPlayerSeatNumber(post.Address), nil
+		}
+		if !g.Dealt() {
+			return 0, fmt.Errorf("%w: waiting for the deal", ErrNoPlayerToAct)
+		}
+		return g.nextBettor()
+	case types.RoundFourthStreet, types.RoundFifthStreet, types.RoundSixthStreet, types.RoundSeventhStreet:
+		return g.nextBettor()
+	case types.RoundShowdown:
+		seat := g.NextSeat(0, func(p *models.Player) bool {
+			return g.Contesting(p) && !g.HasShown(p.Address)
+		})
+		if seat == 0 {
+			return 0, fmt.Errorf("%w: showdown complete", ErrNoPlayerToAct)
+		}
+		return seat, nil
+	default:
+		return 0, fmt.Errorf("%w: hand is over", ErrNoPlayerToAct)
+	}
+}
+
+// nextBettor finds the next player who must act in the current betting round,
+// starting after the last player to act or, with no action yet, with the
+// best hand showing
+func (g *SevenCardStud) nextBettor() (int, error) {
+	if g.HasRoundEnded(g.Round) {
+		return 0, fmt.Errorf("%w: %s betting is complete", ErrNoPlayerToAct, g.Round)
+	}
+
+	bets := g.BetManager(g.Round)
+	largest := bets.GetLargestBet()
+	due := func(p *models.Player) bool {
+		return g.CanAct(p) && (!bets.HasActed(p.Address) || bets.GetBet(p.Address).Cmp(largest) < 0)
+	}
+
+	from := 0
+	turns := g.Turns[g.Round]
+	for i := len(turns) - 1; i >= 0; i-- {
+		if turns[i].Action != types.ActionAnte {
+			from = turns[i].Seat
+			break
+		}
+	}
+	if from == 0 {
+		// The best hand showing may be all-in, so start from the seat before it
+		seat := g.bestShowingSeat()
+		from = (seat+g.Options.MaxPlayers-2)%g.Options.MaxPlayers + 1
+	}
+
+	seat := g.NextSeat(from, due)
+	if seat == 0 {
+		return 0, fmt.Errorf("%w: %s betting is complete", ErrNoPlayerToAct, g.Round)
+	}
+	return seat, nil
+}
+
+// HasRoundEnded reports whether a round of the current hand is complete.
+// Earlier rounds have ended and later rounds have not.
+func (g *SevenCardStud) HasRoundEnded(round types.TexasHoldemRound) bool {
+	if order, current := g.RoundOrder(round), g.RoundOrder(g.Round); order != current {
+		return order < current
+	}
+
+	switch round {
+	case types.RoundThirdStreet, types.RoundFourthStreet, types.RoundFifthStreet,
+		types.RoundSixthStreet, types.RoundSeventhStreet:
+		if round == types.RoundThirdStreet && (!g.Dealt() || g.GetNextPost() != nil) {
+			return false
+		}
+		return g.BettingComplete(round)
+	case types.RoundShowdown:
+		return g.CountPlayers(func(p *models.Player) bool {
+			return g.Contesting(p) && !g.HasShown(p.Address)
+		}) == 0 || g.CountPlayers(g.Contesting) <= 1
+	default:
+		return true
+	}
+}
+
+// GetLegalActions returns the actions the player may take now, with the
+// chips each would move
+func (g *SevenCardStud) GetLegalActions(address string) ([]types.LegalActionDTO, error) {
+	player, err := g.GetPlayer(address)
+	if err != nil {
+		return nil, err
+	}
+
+	seat, err := g.nextToActSeat()
+	if err != nil {
+		return nil, err
+	}
+	if seat != player.Seat {
+		return nil, fmt.Errorf("%w: %s in seat %d, expected seat %d", ErrNotYourTurn, address, player.Seat, seat)
+	}
+
+	var legal []types.LegalActionDTO
+	for _, action := range actions.PlayerActions {
+		a, err := actions.New(g, action)
+		if err != nil {
+			return nil, err
+		}
+		if r, err := a.Verify(player); err == nil {
+			legal = append(legal, types.LegalActionDTO{Action: action, MinAmount: r.MinAmount, MaxAmount: r.MaxAmount})
+		}
+	}
+	return legal, nil
+}
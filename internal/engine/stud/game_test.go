@@ -0,0 +1,201 @@
+package stud
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/engine/enginetest"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// postAntes posts every ante due, then deals third street
+func postAntes(t *testing.T, game *SevenCardStud) {
+	t.Helper()
+
+	for post := game.GetNextPost(); post != nil && post.Action == types.ActionAnte; post = game.GetNextPost() {
+		enginetest.Act(t, game, post.Address, post.Action, post.Amount.Int64())
+	}
+	game.Deal()
+}
+
+// TestSevenCardStud_Hand plays a three-handed hand from the antes to showdown
+func TestSevenCardStud_Hand(t *testing.T) {
+	// A burn then third street to seats 1, 2, 3 in turn, with the third
+	// card up. Each later street burns first. Alice folds on fourth street.
+	deck := enginetest.StackedDeck(t, "AS KH 4C TC QH 6D 7H KS 2H 2C "+
+		"AD 3D 2D 9C AH 5S 9H 3H JD 3S 4H 8S QS")
+	game := enginetest.NewGame(t, NewSevenCardStud, enginetest.Options(types.VariantSevenCardStud), deck, 100, 100, 100)
+	postAntes(t, game)
+
+	t.Run("should bring in from the lowest up-card, clubs before hearts", func(t *testing.T) {
+		if up, _ := game.GetUpCards("carol"); len(up) != 1 || up[0].Mnemonic != "2C" {
+			t.Fatalf("Expected carol to show 2C, got %v", up)
+		}
+		enginetest.ExpectNext(t, game, "carol")
+		legal, err := game.GetLegalActions("carol")
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		if len(legal) != 1 || legal[0].Action != types.ActionBringIn || legal[0].MinAmount.Int64() != 1 || legal[0].MaxAmount.Int64() != 2 {
+			t.Fatalf("Expected a bring-in of 1 to 2, got %+v", legal)
+		}
+		enginetest.Act(t, game, "carol", types.ActionBringIn, 1)
+	})
+
+	t.Run("should complete the bring-in to the small bet", func(t *testing.T) {
+		enginetest.ExpectNext(t, game, "alice")
+		legal, err := game.GetLegalActions("alice")
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		for _, l := range legal {
+			if l.Action == types.ActionRaise && l.MinAmount.Int64() != 2 {
+				t.Errorf("Expected to complete for 2, got %s", l.MinAmount)
+			}
+		}
+		enginetest.Act(t, game, "alice", types.ActionRaise, 2)
+		enginetest.Act(t, game, "bob", types.ActionCall, 2)
+		enginetest.Act(t, game, "carol", types.ActionCall, 1)
+
+		if game.GetCurrentRound() != types.RoundFourthStreet || game.GetPot().Int64() != 9 {
+			t.Fatalf("Expected fourth street with 9 in the pot, got %s with %s", game.GetCurrentRound(), game.GetPot())
+		}
+	})
+
+	t.Run("should start later streets with the best hand showing", func(t *testing.T) {
+		// Bob pairs his deuces
+		enginetest.ExpectNext(t, game, "bob")
+		enginetest.Act(t, game, "bob", types.ActionBet, 2)
+		enginetest.Act(t, game, "carol", types.ActionCall, 2)
+		enginetest.Act(t, game, "alice", types.ActionFold, 0)
+
+		// Carol's nines beat the deuces on fifth street
+		enginetest.ExpectNext(t, game, "carol")
+		if game.GetFixedBet().Int64() != 4 || game.GetRaiseCap() != 0 {
+			t.Errorf("Expected uncapped big bets of 4 heads-up, got %s capped at %d", game.GetFixedBet(), game.GetRaiseCap())
+		}
+		for game.GetCurrentRound() != types.RoundShowdown {
+			next, err := game.GetNextPlayerToAct()
+			if err != nil {
+				t.Fatalf("GetNextPlayerToAct failed: %v", err)
+			}
+			enginetest.Act(t, game, next.GetAddress(), types.ActionCheck, 0)
+		}
+	})
+
+	t.Run("should award the pot to the best seven-card hand", func(t *testing.T) {
+		bob, _ := game.GetPlayer("bob")
+		if len(bob.HoleCards) != 7 {
+			t.Fatalf("Expected seven cards, got %d", len(bob.HoleCards))
+		}
+		enginetest.Act(t, game, "bob", types.ActionShow, 0)
+		enginetest.Act(t, game, "carol", types.ActionShow, 0)
+
+		if game.GetCurrentRound() != types.RoundEnd || enginetest.Chips(t, game, "carol") != 108 {
+			t.Errorf("Expected carol to win 13, got a stack of %d", enginetest.Chips(t, game, "carol"))
+		}
+		if enginetest.Chips(t, game, "alice") != 97 || enginetest.Chips(t, game, "bob") != 95 {
+			t.Errorf("Expected alice 97 and bob 95, got %d and %d", enginetest.Chips(t, game, "alice"), enginetest.Chips(t, game, "bob"))
+		}
+	})
+}
+
+// TestSevenCardStud_SharedCard tests dealing eight players from one deck
+func TestSevenCardStud_SharedCard(t *testing.T) {
+	options := enginetest.Options(types.VariantSevenCardStud)
+	options.Ante = nil
+	game := enginetest.NewGame(t, NewSevenCardStud, options, enginetest.StackedDeck(t, ""), 100, 100, 100, 100, 100, 100, 100, 100)
+	postAntes(t, game)
+
+	// Everyone calls the bring-in and checks to seventh street
+	for game.GetCurrentRound() != types.RoundShowdown {
+		next, err := game.GetNextPlayerToAct()
+		if err != nil {
+			t.Fatalf("GetNextPlayerToAct failed: %v", err)
+		}
+		legal, err := game.GetLegalActions(next.GetAddress())
+		if err != nil {
+			t.Fatalf("GetLegalActions failed: %v", err)
+		}
+		choice := legal[0]
+		for _, l := range legal {
+			if l.Action == types.ActionBringIn || l.Action == types.ActionCall || l.Action == types.ActionCheck {
+				choice = l
+			}
+		}
+		enginetest.Act(t, game, next.GetAddress(), choice.Action, choice.MinAmount.Int64())
+	}
+
+	if len(game.GetCommunityCards()) != 1 || game.GetDeck().Remaining() != 0 {
+		t.Fatalf("Expected a shared card from the last of the deck, got %v with %d left",
+			game.GetCommunityCards(), game.GetDeck().Remaining())
+	}
+	for _, p := range game.GetPlayers() {
+		if len(p.HoleCards) != 6 {
+			t.Errorf("Expected %s to hold six cards, got %d", p.Address, len(p.HoleCards))
+		}
+	}
+
+	for _, p := range game.GetPlayers() {
+		enginetest.Act(t, game, p.Address, types.ActionShow, 0)
+	}
+	if game.GetCurrentRound() != types.RoundEnd || len(game.GetWinners()) == 0 {
+		t.Errorf("Expected the pot to be awarded using the shared card")
+	}
+}
+
+// TestUpScore tests ranking up cards to find the first player to act
+func TestUpScore(t *testing.T) {
+	hand := func(mnemonics string) []types.Card {
+		var cards []types.Card
+		for _, mnemonic := range strings.Fields(mnemonics) {
+			card, err := models.FromString(mnemonic)
+			if err != nil {
+				t.Fatalf("FromString(%s) failed: %v", mnemonic, err)
+			}
+			cards = append(cards, card)
+		}
+		return cards
+	}
+
+	// Each hand beats the next
+	ordered := []string{"5C 5D 5H 5S", "2C 2D 2H AS", "KC KD 3H 3S", "AC AD KH QS", "AC KD QH JS", "AC KD QH 9S"}
+	for i := 0; i+1 < len(ordered); i++ {
+		if compareScores(upScore(hand(ordered[i])), upScore(hand(ordered[i+1]))) <= 0 {
+			t.Errorf("Expected %s to beat %s", ordered[i], ordered[i+1])
+		}
+	}
+
+	if !lowerCard(hand("2C")[0], hand("2D")[0]) || lowerCard(hand("AC")[0], hand("KS")[0]) {
+		t.Error("Expected suits to break ties and aces to be high for the bring-in")
+	}
+}
+
+// TestNewSevenCardStud tests option validation
+func TestNewSevenCardStud(t *testing.T) {
+	for name, change := range map[string]func(*types.GameOptions){
+		"no-limit":       func(o *types.GameOptions) { o.Betting = types.BettingNoLimit },
+		"nine players":   func(o *types.GameOptions) { o.MaxPlayers = 9 },
+		"no bring-in":    func(o *types.GameOptions) { o.BringIn = nil },
+		"holdem variant": func(o *types.GameOptions) { o.Variant = types.VariantTexasHoldem },
+		"big bring-in":   func(o *types.GameOptions) { o.BringIn = big.NewInt(3) },
+		"button antes":   func(o *types.GameOptions) { o.AnteType = types.AnteButton },
+	} {
+		options := enginetest.Options(types.VariantSevenCardStud)
+		change(&options)
+		if _, err := NewSevenCardStud(options, ""); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%s: expected ErrInvalidOptions, got %v", name, err)
+		}
+	}
+
+	game, err := NewSevenCardStud(enginetest.Options(types.VariantSevenCardStud), "")
+	if err != nil {
+		t.Fatalf("NewSevenCardStud failed: %v", err)
+	}
+	if err := game.SetHandOptions(types.HandOptions{BombPot: big.NewInt(2)}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected a stud bomb pot to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/block52/go-pvm/internal/engine/holdem"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by the tournament controller
+var (
+	ErrInvalidOptions = errors.New("invalid tournament options")
+	ErrFinished       = errors.New("tournament is finished")
+	ErrHandInProgress = holdem.ErrHandInProgress
+)
+
+// SitAndGo runs a single-table tournament on a Texas Hold'em table. Players
+// act on the table returned by GetGame, and once each hand is over NewHand
+// knocks out the players left without chips, raises the blinds when the
+// level is up and starts the next hand. When one player remains the prize
+// pool is paid out by finishing place.
+//
+// Nobody may join once the first hand has started. A player who leaves
+// keeps their seat sitting out, and their stack is blinded off until they
+// are knocked out and placed.
+type SitAndGo struct {
+	options    types.SitAndGoOptions
+	game       *holdem.TexasHoldem
+	level      int                 // Index of the current blind level
+	levelHands int                 // Hands completed at the current level
+	levelStart time.Time           // When the current level began
+	stacks     map[string]*big.Int // Chips each player started the current hand with
+	results    []types.TournamentResult
+	finished   bool
+	now        func() time.Time
+}
+
+// NewSitAndGo seats the players in seats 1, 2, 3... with equal stacks and
+// sets the blinds of the first level. The first hand deals from deck.
+func NewSitAndGo(options types.SitAndGoOptions, players []string, deck string) (*SitAndGo, error) {
+	game := options.Game
+	switch game.Format {
+	case "":
+		game.Format = types.FormatSitAndGo
+	case types.FormatSitAndGo:
+	default:
+		return nil, fmt.Errorf("%w: %s is not a Sit-and-Go", ErrInvalidOptions, game.Format)
+	}
+	if game.MinPlayers == 0 {
+		game.MinPlayers = 2
+	}
+	if game.MaxPlayers == 0 {
+		game.MaxPlayers = len(players)
+	}
+	if len(players) < 2 || len(players) > game.MaxPlayers {
+		return nil, fmt.Errorf("%w: %d players for %d seats", ErrInvalidOptions, len(players), game.MaxPlayers)
+	}
+	if options.StartingChips == nil || options.StartingChips.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: starting chips must be positive", ErrInvalidOptions)
+	}
+
+	if len(options.Levels) == 0 {
+		return nil, fmt.Errorf("%w: no blind levels", ErrInvalidOptions)
+	}
+	for i, level := range options.Levels {
+		if level.SmallBlind == nil || level.BigBlind == nil ||
+			level.SmallBlind.Sign() <= 0 || level.BigBlind.Cmp(level.SmallBlind) < 0 {
+			return nil, fmt.Errorf("%w: level %d blinds must be positive with big blind >= small blind", ErrInvalidOptions, i+1)
+		}
+		if level.Ante != nil && level.Ante.Sign() < 0 {
+			return nil, fmt.Errorf("%w: level %d has a negative ante", ErrInvalidOptions, i+1)
+		}
+		if level.Hands < 0 || level.Duration < 0 {
+			return nil, fmt.Errorf("%w: level %d has a negative length", ErrInvalidOptions, i+1)
+		}
+		if level.Hands == 0 && level.Duration == 0 && i < len(options.Levels)-1 {
+			return nil, fmt.Errorf("%w: level %d never ends", ErrInvalidOptions, i+1)
+		}
+	}
+
+	if options.PrizePool == nil {
+		options.PrizePool = big.NewInt(0)
+	}
+	if options.PrizePool.Sign() < 0 {
+		return nil, fmt.Errorf("%w: negative prize pool %s", ErrInvalidOptions, options.PrizePool)
+	}
+	if len(options.Payouts) == 0 || len(options.Payouts) > len(players) {
+		return nil, fmt.Errorf("%w: %d places paid to %d players", ErrInvalidOptions, len(options.Payouts), len(players))
+	}
+	total := 0
+	for _, share := range options.Payouts {
+		if share <= 0 {
+			return nil, fmt.Errorf("%w: payout of %d basis points", ErrInvalidOptions, share)
+		}
+		total += share
+	}
+	if total != 10000 {
+		return nil, fmt.Errorf("%w: payouts add up to %d basis points, not 10000", ErrInvalidOptions, total)
+	}
+
+	// Everyone starts with the same stack and nobody buys in at the table
+	first := options.Levels[0]
+	game.SmallBlind = first.SmallBlind
+	game.BigBlind = first.BigBlind
+	game.Ante = first.Ante
+	game.MinBuyIn = options.StartingChips
+	game.MaxBuyIn = options.StartingChips
+
+	g, err := holdem.NewTexasHoldem(game, deck)
+	if err != nil {
+		return nil, err
+	}
+	for i, address := range players {
+		if err := g.AddPlayer(models.NewPlayer(address, new(big.Int).Set(options.StartingChips), i+1)); err != nil {
+			return nil, err
+		}
+	}
+
+	options.Game = game
+	s := &SitAndGo{
+		options: options,
+		game:    g,
+		now:     options.Clock,
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	s.levelStart = s.now()
+	s.recordStacks()
+	return s, nil
+}
+
+// GetGame returns the table the tournament is played on
+func (s *SitAndGo) GetGame() *holdem.TexasHoldem {
+	return s.game
+}
+
+// GetLevel returns the number of the current blind level, starting at 1
+func (s *SitAndGo) GetLevel() int {
+	return s.level + 1
+}
+
+// GetBlindLevel returns the blinds and ante of the current level
+func (s *SitAndGo) GetBlindLevel() types.BlindLevel {
+	return s.options.Levels[s.level]
+}
+
+// IsFinished reports whether one player remains and the prizes are paid
+func (s *SitAndGo) IsFinished() bool {
+	return s.finished
+}
+
+// GetResults returns the players knocked out so far, and the winner once the
+// tournament is finished, best place first. Prizes are filled in when the
+// tournament finishes.
+func (s *SitAndGo) GetResults() []types.TournamentResult {
+	results := append([]types.TournamentResult(nil), s.results...)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Position < results[j].Position
+	})
+	return results
+}
+
+// NewHand ends the hand just played: players left without chips are busted,
+// and if only one player remains the tournament is finished and paid out.
+// Otherwise the blinds rise if the level is up and the next hand deals from
+// deck.
+func (s *SitAndGo) NewHand(deck string) error {
+	if s.finished {
+		return ErrFinished
+	}
+	if round := s.game.GetCurrentRound(); round != types.RoundEnd {
+		return fmt.Errorf("%w: %s", ErrHandInProgress, round)
+	}
+	if _, err := models.NewDeckWithComposition(deck, models.CompositionForVariant(s.game.GetGameVariant())); err != nil {
+		return err
+	}
+
+	s.eliminate()
+	if s.remaining() == 1 {
+		s.payout()
+		return nil
+	}
+
+	s.levelHands++
+	s.advanceLevel()
+	level := s.options.Levels[s.level]
+	if err := s.game.SetBlinds(level.SmallBlind, level.BigBlind, level.Ante); err != nil {
+		return err
+	}
+	if err := s.game.ReInit(deck); err != nil {
+		return err
+	}
+	s.recordStacks()
+	return nil
+}
+
+// recordStacks notes the chips each player starts the hand with
+func (s *SitAndGo) recordStacks() {
+	s.stacks = make(map[string]*big.Int)
+	for _, p := range s.game.GetPlayers() {
+		s.stacks[p.Address] = new(big.Int).Set(p.Chips)
+	}
+}
+
+// remaining counts the players not yet knocked out
+func (s *SitAndGo) remaining() int {
+	count := 0
+	for _, p := range s.game.GetPlayers() {
+		if p.Status != types.StatusBusted {
+			count++
+		}
+	}
+	return count
+}
+
+// eliminate busts the players left without chips by the last hand. Players
+// knocked out in the same hand finish in order of the chips they started it
+// with, the bigger stack placing higher; equal stacks place in seat order,
+// the lower seat placing lower.
+func (s *SitAndGo) eliminate() {
+	var busted []*models.Player
+	for _, p := range s.game.GetPlayers() {
+		if p.Status != types.StatusBusted && p.Chips.Sign() == 0 {
+			busted = append(busted, p)
+		}
+	}
+	sort.SliceStable(busted, func(i, j int) bool {
+		return s.startingStack(busted[i]).Cmp(s.startingStack(busted[j])) < 0
+	})
+
+	position := s.remaining()
+	for _, p := range busted {
+		p.Status = types.StatusBusted
+		s.results = append(s.results, types.TournamentResult{
+			Address:  p.Address,
+			Position: position,
+			Prize:    big.NewInt(0),
+			Hand:     s.game.GetHandNumber(),
+		})
+		position--
+	}
+}
+
+// startingStack returns the chips a player started the last hand with.
+// Players who joined the table before the first hand started with the
+// starting chips.
+func (s *SitAndGo) startingStack(p *models.Player) *big.Int {
+	if stack, ok := s.stacks[p.Address]; ok {
+		return stack
+	}
+	return s.options.StartingChips
+}
+
+// advanceLevel moves to the next blind level once the current one has run
+// its hands or its time. Timed levels run back to back from the start of the
+// tournament, so a long hand may skip a level.
+func (s *SitAndGo) advanceLevel() {
+	now := s.now()
+	for s.level+1 < len(s.options.Levels) {
+		level := s.options.Levels[s.level]
+		byHands := level.Hands > 0 && s.levelHands >= level.Hands
+		byTime := level.Duration > 0 && !now.Before(s.levelStart.Add(level.Duration))
+		if !byHands && !byTime {
+			return
+		}
+
+		if byTime {
+			s.levelStart = s.levelStart.Add(level.Duration)
+		} else {
+			s.levelStart = now
+		}
+		s.level++
+		s.levelHands = 0
+	}
+}
+
+// payout records the last player standing as the winner and shares the prize
+// pool between the places paid. Chips lost to rounding go to the winner.
+func (s *SitAndGo) payout() {
+	for _, p := range s.game.GetPlayers() {
+		if p.Status != types.StatusBusted {
+			s.results = append(s.results, types.TournamentResult{
+				Address:  p.Address,
+				Position: 1,
+				Hand:     s.game.GetHandNumber(),
+			})
+		}
+	}
+
+	prizes := make([]*big.Int, len(s.options.Payouts))
+	left := new(big.Int).Set(s.options.PrizePool)
+	for i, share := range s.options.Payouts {
+		prizes[i] = new(big.Int).Mul(s.options.PrizePool, big.NewInt(int64(share)))
+		prizes[i].Quo(prizes[i], big.NewInt(10000))
+		left.Sub(left, prizes[i])
+	}
+	prizes[0].Add(prizes[0], left)
+
+	for i := range s.results {
+		s.results[i].Prize = big.NewInt(0)
+		if position := s.results[i].Position; position <= len(prizes) {
+			s.results[i].Prize = prizes[position-1]
+		}
+	}
+	s.finished = true
+}
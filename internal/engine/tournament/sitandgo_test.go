@@ -0,0 +1,368 @@
+package tournament
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/block52/go-pvm/internal/engine/holdem"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// stackedDeck returns a deck string with the given mnemonics on top, followed
+// by the rest of a standard deck in order
+func stackedDeck(t testing.TB, top string) string {
+	t.Helper()
+
+	first := strings.Fields(top)
+	used := make(map[string]bool, len(first))
+	for _, mnemonic := range first {
+		used[mnemonic] = true
+	}
+
+	standard, err := models.NewDeck("")
+	if err != nil {
+		t.Fatalf("NewDeck failed: %v", err)
+	}
+	cards := append([]string(nil), first...)
+	for _, mnemonic := range strings.Split(strings.Trim(standard.ToString(), "[]"), "-") {
+		mnemonic = strings.Trim(mnemonic, "[]")
+		if !used[mnemonic] {
+			cards = append(cards, mnemonic)
+		}
+	}
+	cards[0] = "[" + cards[0] + "]"
+	return strings.Join(cards, "-")
+}
+
+// testOptions returns a three-player Sit-and-Go of 100 chips a player, paying
+// 70% and 30% of a 300 prize pool
+func testOptions(levels ...types.BlindLevel) types.SitAndGoOptions {
+	return types.SitAndGoOptions{
+		Game:          types.GameOptions{Variant: types.VariantTexasHoldem},
+		StartingChips: big.NewInt(100),
+		Levels:        levels,
+		PrizePool:     big.NewInt(300),
+		Payouts:       []int{7000, 3000},
+	}
+}
+
+// level returns a blind level without an ante
+func level(small, large int64, hands int, duration time.Duration) types.BlindLevel {
+	return types.BlindLevel{SmallBlind: big.NewInt(small), BigBlind: big.NewInt(large), Hands: hands, Duration: duration}
+}
+
+// act performs an action with the next index, failing the test on error
+func act(t *testing.T, s *SitAndGo, address string, action types.PlayerActionType, amount int64) {
+	t.Helper()
+
+	game := s.GetGame()
+	if err := game.PerformAction(address, action, game.GetActionIndex(), big.NewInt(amount)); err != nil {
+		t.Fatalf("%s %s %d failed: %v", address, action, amount, err)
+	}
+}
+
+// postBlinds posts the blinds and any antes due, then deals
+func postBlinds(t *testing.T, s *SitAndGo) {
+	t.Helper()
+
+	for post := s.GetGame().GetNextPost(); post != nil; post = s.GetGame().GetNextPost() {
+		act(t, s, post.Address, post.Action, post.Amount.Int64())
+	}
+	s.GetGame().Deal()
+}
+
+// foldAround has every player to act fold until the hand is over
+func foldAround(t *testing.T, s *SitAndGo) {
+	t.Helper()
+
+	for s.GetGame().GetCurrentRound() != types.RoundEnd {
+		next, err := s.GetGame().GetNextPlayerToAct()
+		if err != nil {
+			t.Fatalf("GetNextPlayerToAct failed: %v", err)
+		}
+		act(t, s, next.GetAddress(), types.ActionFold, 0)
+	}
+}
+
+// TestSitAndGo plays a three-player Sit-and-Go to the finish
+func TestSitAndGo(t *testing.T) {
+	s, err := NewSitAndGo(testOptions(level(1, 2, 1, 0), level(5, 10, 0, 0)),
+		[]string{"alice", "bob", "carol"}, stackedDeck(t, "2C 7D AS 3C 2D AH 4H 8S 9C JD 5H KC 6H QD"))
+	if err != nil {
+		t.Fatalf("NewSitAndGo failed: %v", err)
+	}
+	game := s.GetGame()
+
+	t.Run("should seat everyone with equal stacks", func(t *testing.T) {
+		if game.GetGameFormat() != types.FormatSitAndGo || len(game.GetPlayers()) != 3 {
+			t.Fatalf("Expected a three-player Sit-and-Go, got %s with %d", game.GetGameFormat(), len(game.GetPlayers()))
+		}
+		for _, p := range game.GetPlayers() {
+			if p.Chips.Int64() != 100 {
+				t.Errorf("Expected %s to start with 100, got %s", p.Address, p.Chips)
+			}
+		}
+	})
+
+	t.Run("should bust a player and raise the blinds after the level's hands", func(t *testing.T) {
+		// Alice's aces against carol's seven-deuce, dealt from seat 2
+		postBlinds(t, s)
+		act(t, s, "alice", types.ActionAllIn, 100)
+		act(t, s, "bob", types.ActionFold, 0)
+		act(t, s, "carol", types.ActionAllIn, 98)
+		act(t, s, "carol", types.ActionShow, 0)
+		act(t, s, "alice", types.ActionShow, 0)
+
+		// Heads-up bob has the button, so alice is dealt aces first
+		if err := s.NewHand(stackedDeck(t, "AS 7D AH 2C 4H 8S 9C JD 5H KC 6H QD")); err != nil {
+			t.Fatalf("NewHand failed: %v", err)
+		}
+		carol, _ := game.GetPlayer("carol")
+		if carol.Status != types.StatusBusted {
+			t.Errorf("Expected carol to be busted, got %s", carol.Status)
+		}
+		results := s.GetResults()
+		if len(results) != 1 || results[0].Address != "carol" || results[0].Position != 3 || results[0].Hand != 1 {
+			t.Errorf("Expected carol to finish third in hand 1, got %+v", results)
+		}
+		if s.GetLevel() != 2 || game.GetBigBlind().Int64() != 10 {
+			t.Errorf("Expected level 2 with a big blind of 10, got level %d with %s", s.GetLevel(), game.GetBigBlind())
+		}
+	})
+
+	t.Run("should not deal a busted player in", func(t *testing.T) {
+		postBlinds(t, s)
+		carol, _ := game.GetPlayer("carol")
+		if len(carol.HoleCards) != 0 || len(game.FindActivePlayers()) != 2 {
+			t.Errorf("Expected carol to sit the hand out")
+		}
+		if err := s.NewHand(stackedDeck(t, "")); !errors.Is(err, ErrHandInProgress) {
+			t.Errorf("Expected ErrHandInProgress, got %v", err)
+		}
+	})
+
+	t.Run("should pay out when one player remains", func(t *testing.T) {
+		act(t, s, "bob", types.ActionAllIn, 94)
+		act(t, s, "alice", types.ActionCall, 89)
+		act(t, s, "alice", types.ActionShow, 0)
+		act(t, s, "bob", types.ActionShow, 0)
+
+		if err := s.NewHand(stackedDeck(t, "")); err != nil {
+			t.Fatalf("NewHand failed: %v", err)
+		}
+		if !s.IsFinished() {
+			t.Fatal("Expected the tournament to be finished")
+		}
+
+		results := s.GetResults()
+		if len(results) != 3 || results[0].Address != "alice" || results[1].Address != "bob" || results[1].Hand != 2 {
+			t.Fatalf("Expected alice to win and bob to finish second in hand 2, got %+v", results)
+		}
+		if results[0].Prize.Int64() != 210 || results[1].Prize.Int64() != 90 || results[2].Prize.Sign() != 0 {
+			t.Errorf("Expected prizes of 210, 90 and 0, got %s, %s and %s", results[0].Prize, results[1].Prize, results[2].Prize)
+		}
+		if err := s.NewHand(stackedDeck(t, "")); !errors.Is(err, ErrFinished) {
+			t.Errorf("Expected ErrFinished, got %v", err)
+		}
+	})
+}
+
+// TestSitAndGo_TimedLevels tests raising the blinds by elapsed time
+func TestSitAndGo_TimedLevels(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	options := testOptions(level(1, 2, 0, 10*time.Minute), level(2, 4, 0, 10*time.Minute), level(5, 10, 0, 0))
+	options.Clock = func() time.Time { return now }
+	s, err := NewSitAndGo(options, []string{"alice", "bob", "carol"}, stackedDeck(t, ""))
+	if err != nil {
+		t.Fatalf("NewSitAndGo failed: %v", err)
+	}
+
+	postBlinds(t, s)
+	foldAround(t, s)
+	now = now.Add(9 * time.Minute)
+	if err := s.NewHand(stackedDeck(t, "")); err != nil {
+		t.Fatalf("NewHand failed: %v", err)
+	}
+	if s.GetLevel() != 1 {
+		t.Errorf("Expected level 1 after 9 minutes, got %d", s.GetLevel())
+	}
+
+	// A long hand runs past the end of the second level too
+	postBlinds(t, s)
+	foldAround(t, s)
+	now = now.Add(12 * time.Minute)
+	if err := s.NewHand(stackedDeck(t, "")); err != nil {
+		t.Fatalf("NewHand failed: %v", err)
+	}
+	if s.GetLevel() != 3 || s.GetGame().GetSmallBlind().Int64() != 5 {
+		t.Errorf("Expected level 3 after 21 minutes, got %d with a small blind of %s", s.GetLevel(), s.GetGame().GetSmallBlind())
+	}
+}
+
+// TestSitAndGo_Eliminate tests placing players knocked out in the same hand
+func TestSitAndGo_Eliminate(t *testing.T) {
+	s, err := NewSitAndGo(testOptions(level(1, 2, 0, 0)), []string{"alice", "bob", "carol", "dave"}, stackedDeck(t, ""))
+	if err != nil {
+		t.Fatalf("NewSitAndGo failed: %v", err)
+	}
+	postBlinds(t, s)
+	foldAround(t, s)
+
+	// Dave started the hand with more chips than bob, so finishes higher
+	s.stacks["dave"] = big.NewInt(150)
+	for _, address := range []string{"bob", "dave"} {
+		p, _ := s.GetGame().GetPlayer(address)
+		p.Chips = big.NewInt(0)
+	}
+	if err := s.NewHand(stackedDeck(t, "")); err != nil {
+		t.Fatalf("NewHand failed: %v", err)
+	}
+
+	results := s.GetResults()
+	if len(results) != 2 || results[0].Address != "dave" || results[0].Position != 3 ||
+		results[1].Address != "bob" || results[1].Position != 4 {
+		t.Errorf("Expected dave third and bob fourth, got %+v", results)
+	}
+}
+
+// TestSitAndGo_Join tests that nobody joins once the tournament has started
+func TestSitAndGo_Join(t *testing.T) {
+	options := testOptions(level(1, 2, 0, 0))
+	options.Game.MaxPlayers = 4
+	s, err := NewSitAndGo(options, []string{"alice", "bob", "carol"}, stackedDeck(t, ""))
+	if err != nil {
+		t.Fatalf("NewSitAndGo failed: %v", err)
+	}
+	game := s.GetGame()
+
+	join := func() error {
+		return game.PerformNonPlayerAction("dave", types.ActionJoin, game.GetActionIndex(), big.NewInt(100), "")
+	}
+	act(t, s, "bob", types.ActionSmallBlind, 1)
+	if err := join(); !errors.Is(err, holdem.ErrSeatingClosed) {
+		t.Errorf("Expected ErrSeatingClosed during the first hand, got %v", err)
+	}
+
+	act(t, s, "carol", types.ActionBigBlind, 2)
+	game.Deal()
+	foldAround(t, s)
+	if err := s.NewHand(stackedDeck(t, "")); err != nil {
+		t.Fatalf("NewHand failed: %v", err)
+	}
+	if err := join(); !errors.Is(err, holdem.ErrSeatingClosed) {
+		t.Errorf("Expected ErrSeatingClosed between hands, got %v", err)
+	}
+	if len(game.GetPlayers()) != 3 {
+		t.Errorf("Expected three players, got %d", len(game.GetPlayers()))
+	}
+}
+
+// TestSitAndGo_Leave tests that a player who leaves is blinded off and still
+// finishes in a place
+func TestSitAndGo_Leave(t *testing.T) {
+	s, err := NewSitAndGo(testOptions(level(50, 100, 0, 0)),
+		[]string{"alice", "bob", "carol"}, stackedDeck(t, "2C 7D AS 3C 2D AH 4H 8S 9C JD 5H KC 6H QD"))
+	if err != nil {
+		t.Fatalf("NewSitAndGo failed: %v", err)
+	}
+	game := s.GetGame()
+
+	if err := game.PerformNonPlayerAction("carol", types.ActionLeave, game.GetActionIndex(), nil, ""); err != nil {
+		t.Fatalf("LEAVE failed: %v", err)
+	}
+	carol, err := game.GetPlayer("carol")
+	if err != nil {
+		t.Fatalf("Expected carol to keep her seat, got %v", err)
+	}
+	if !game.IsSittingOut("carol") || carol.Chips.Int64() != 100 {
+		t.Fatalf("Expected carol sitting out with 100 chips, got %s", carol.Chips)
+	}
+	log := game.GetActionLog()
+	if leave := log[len(log)-1]; leave.Action != types.ActionLeave || leave.Amount.Sign() != 0 {
+		t.Fatalf("Expected LEAVE logged with no cash-out, got %s %s", leave.Action, leave.Amount)
+	}
+
+	// Carol's big blind is posted for her, putting her all-in, and her hand
+	// is shown for her at showdown
+	postBlinds(t, s)
+	if carol.Status != types.StatusAllIn {
+		t.Fatalf("Expected carol all-in for the big blind, got %s", carol.Status)
+	}
+	act(t, s, "alice", types.ActionAllIn, 100)
+	act(t, s, "bob", types.ActionFold, 0)
+	act(t, s, "alice", types.ActionShow, 0)
+	if game.GetCurrentRound() != types.RoundEnd {
+		t.Fatalf("Expected the hand to be over, got %s", game.GetCurrentRound())
+	}
+
+	if err := s.NewHand(stackedDeck(t, "")); err != nil {
+		t.Fatalf("NewHand failed: %v", err)
+	}
+	results := s.GetResults()
+	if len(results) != 1 || results[0].Address != "carol" || results[0].Position != 3 {
+		t.Errorf("Expected carol to finish third, got %+v", results)
+	}
+}
+
+// TestSitAndGo_BlindOff tests a player sitting out posting and folding
+func TestSitAndGo_BlindOff(t *testing.T) {
+	s, err := NewSitAndGo(testOptions(level(1, 2, 0, 0)), []string{"alice", "bob", "carol"}, stackedDeck(t, ""))
+	if err != nil {
+		t.Fatalf("NewSitAndGo failed: %v", err)
+	}
+	game := s.GetGame()
+	postBlinds(t, s)
+	foldAround(t, s)
+
+	// Carol leaves between hands and is due the small blind of the next,
+	// which is posted for her as soon as it starts
+	if err := game.PerformNonPlayerAction("carol", types.ActionLeave, game.GetActionIndex(), nil, ""); err != nil {
+		t.Fatalf("LEAVE failed: %v", err)
+	}
+	if err := s.NewHand(stackedDeck(t, "")); err != nil {
+		t.Fatalf("NewHand failed: %v", err)
+	}
+	if post := game.GetNextPost(); post == nil || post.Address != "alice" || post.Action != types.ActionBigBlind {
+		t.Fatalf("Expected alice's big blind to be due after carol's small blind, got %+v", post)
+	}
+	postBlinds(t, s)
+
+	// Bob on the button raises and carol folds when her turn comes, losing
+	// the small blind she won back in the first hand
+	act(t, s, "bob", types.ActionRaise, 4)
+	carol, _ := game.GetPlayer("carol")
+	if carol.Status != types.StatusFolded || carol.Chips.Int64() != 100 {
+		t.Errorf("Expected carol to fold her small blind, got %s with %s chips", carol.Status, carol.Chips)
+	}
+	next, err := game.GetNextPlayerToAct()
+	if err != nil || next.GetAddress() != "alice" {
+		t.Errorf("Expected alice to act next, got %v", err)
+	}
+}
+
+// TestNewSitAndGo tests option validation
+func TestNewSitAndGo(t *testing.T) {
+	players := []string{"alice", "bob", "carol"}
+	for name, change := range map[string]func(*types.SitAndGoOptions){
+		"cash game":         func(o *types.SitAndGoOptions) { o.Game.Format = types.FormatCash },
+		"no chips":          func(o *types.SitAndGoOptions) { o.StartingChips = nil },
+		"no levels":         func(o *types.SitAndGoOptions) { o.Levels = nil },
+		"endless level":     func(o *types.SitAndGoOptions) { o.Levels = append([]types.BlindLevel{level(1, 2, 0, 0)}, o.Levels...) },
+		"inverted blinds":   func(o *types.SitAndGoOptions) { o.Levels[0] = level(2, 1, 5, 0) },
+		"short payouts":     func(o *types.SitAndGoOptions) { o.Payouts = []int{6000, 3000} },
+		"too many places":   func(o *types.SitAndGoOptions) { o.Payouts = []int{5000, 2500, 1500, 1000} },
+		"negative prize":    func(o *types.SitAndGoOptions) { o.PrizePool = big.NewInt(-1) },
+		"too few seats":     func(o *types.SitAndGoOptions) { o.Game.MaxPlayers = 2 },
+		"zero share payout": func(o *types.SitAndGoOptions) { o.Payouts = []int{10000, 0} },
+	} {
+		options := testOptions(level(1, 2, 5, 0), level(2, 4, 0, 0))
+		change(&options)
+		if _, err := NewSitAndGo(options, players, ""); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%s: expected ErrInvalidOptions, got %v", name, err)
+		}
+	}
+}
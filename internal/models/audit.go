@@ -0,0 +1,187 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// ErrAuditMismatch is returned when an audit log does not match its deck
+var ErrAuditMismatch = errors.New("audit log does not match deck")
+
+// Destination describes where a card went when it left the deck
+type Destination string
+
+const (
+	DestinationHole       Destination = "HOLE"       // Hole card for a seat
+	DestinationBoard      Destination = "BOARD"      // Community card
+	DestinationBurn       Destination = "BURN"       // Burned before a street
+	DestinationUnassigned Destination = "UNASSIGNED" // Dealt with GetNext or Deal
+	DestinationReshuffle  Destination = "RESHUFFLE"  // Returned to the bottom of the deck by Reshuffle
+)
+
+// AuditEntry records a single card leaving the deck, or a dealt card going
+// back into it when the deck is reshuffled
+type AuditEntry struct {
+	Position    int         `json:"position"`
+	Card        string      `json:"card"`
+	Destination Destination `json:"destination"`
+	Seat        int         `json:"seat,omitempty"`       // Only set for hole cards
+	Commitment  string      `json:"commitment,omitempty"` // hex(SHA256(seed)), only set for reshuffled cards
+}
+
+// DeckAudit is a deck serialized together with its audit log. Once cards
+// have been reshuffled, Start holds the deck as it stood before the first
+// reshuffle, which the log is replayed from.
+type DeckAudit struct {
+	Deck    string       `json:"deck"`
+	Hash    string       `json:"hash"`
+	Start   string       `json:"start,omitempty"`
+	Entries []AuditEntry `json:"entries"`
+}
+
+// GetAuditLog returns every card that has left the deck since it was created
+// or last shuffled, and every card reshuffled back in, in the order they moved
+func (d *Deck) GetAuditLog() []AuditEntry {
+	return append([]AuditEntry(nil), d.audit...)
+}
+
+// Audit serializes the deck with its audit log
+func (d *Deck) Audit() DeckAudit {
+	audit := DeckAudit{
+		Deck:    d.ToString(),
+		Hash:    d.GetHash(),
+		Entries: d.GetAuditLog(),
+	}
+	if d.start != nil {
+		start := &Deck{cards: d.start, top: d.startTop}
+		audit.Start = start.ToString()
+	}
+	return audit
+}
+
+// VerifyAudit checks that an audit log is consistent with its deck: the deck
+// string hashes to the recorded hash, entries cover consecutive positions
+// ending at the top of the deck, and every recorded card is the card at that
+// position. A log with reshuffles is replayed from its start deck, checking
+// each reshuffle returned dealt cards below the undealt ones and ends at the
+// audited deck.
+func VerifyAudit(audit DeckAudit) error {
+	deck, err := NewDeck(audit.Deck)
+	if err != nil {
+		return fmt.Errorf("invalid audited deck: %w", err)
+	}
+	if deck.GetHash() != audit.Hash {
+		return fmt.Errorf("%w: hash %s does not match deck", ErrAuditMismatch, audit.Hash)
+	}
+
+	start := deck
+	if audit.Start != "" {
+		if start, err = NewDeck(audit.Start); err != nil {
+			return fmt.Errorf("invalid audited start deck: %w", err)
+		}
+	}
+	return start.verifyEntries(audit.Entries, deck)
+}
+
+// verifyEntries replays audit entries over the cards of the deck and checks
+// they end at the final deck
+func (d *Deck) verifyEntries(entries []AuditEntry, final *Deck) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	cards := append([]types.Card(nil), d.cards...)
+	next := entries[0].Position
+	if entries[0].Destination == DestinationReshuffle {
+		next = d.top
+	}
+	for i := 0; i < len(entries); {
+		entry := entries[i]
+		if entry.Destination == DestinationReshuffle {
+			j := i
+			for j < len(entries) && entries[j].Destination == DestinationReshuffle &&
+				entries[j].Commitment == entry.Commitment {
+				j++
+			}
+			var err error
+			if cards, next, err = replayReshuffle(cards, next, entries[i:j]); err != nil {
+				return err
+			}
+			i = j
+			continue
+		}
+
+		if entry.Position != next || entry.Position < 0 || entry.Position >= len(cards) {
+			return fmt.Errorf("%w: unexpected position %d", ErrAuditMismatch, entry.Position)
+		}
+		if cards[entry.Position].Mnemonic != entry.Card {
+			return fmt.Errorf("%w: position %d holds %s, not %s",
+				ErrAuditMismatch, entry.Position, cards[entry.Position].Mnemonic, entry.Card)
+		}
+		switch entry.Destination {
+		case DestinationHole:
+			if entry.Seat <= 0 {
+				return fmt.Errorf("%w: hole card at position %d has no seat", ErrAuditMismatch, entry.Position)
+			}
+		case DestinationBoard, DestinationBurn, DestinationUnassigned:
+		default:
+			return fmt.Errorf("%w: unknown destination %q", ErrAuditMismatch, entry.Destination)
+		}
+		next++
+		i++
+	}
+
+	if len(cards) != len(final.cards) {
+		return fmt.Errorf("%w: replayed %d cards, deck has %d", ErrAuditMismatch, len(cards), len(final.cards))
+	}
+	for i, card := range cards {
+		if final.cards[i].Mnemonic != card.Mnemonic {
+			return fmt.Errorf("%w: replay puts %s at position %d, deck has %s",
+				ErrAuditMismatch, card.Mnemonic, i, final.cards[i].Mnemonic)
+		}
+	}
+	// A fully dealt deck serializes without a position marker
+	if next < len(cards) && next != final.top {
+		return fmt.Errorf("%w: log ends at position %d but deck top is %d", ErrAuditMismatch, next, final.top)
+	}
+	return nil
+}
+
+// replayReshuffle applies the entries of one reshuffle to cards dealt up to
+// top: the returned cards must have been dealt, the other dealt cards close
+// up at the front and the returned cards follow the undealt ones in the
+// logged order. It returns the new order and top.
+func replayReshuffle(cards []types.Card, top int, entries []AuditEntry) ([]types.Card, int, error) {
+	dealt := make(map[string]types.Card, top)
+	for _, card := range cards[:top] {
+		dealt[card.Mnemonic] = card
+	}
+
+	returned := make(map[string]bool, len(entries))
+	back := make([]types.Card, 0, len(entries))
+	for _, entry := range entries {
+		card, ok := dealt[entry.Card]
+		if !ok || returned[entry.Card] {
+			return nil, 0, fmt.Errorf("%w: %s reshuffled without being dealt", ErrAuditMismatch, entry.Card)
+		}
+		returned[entry.Card] = true
+		back = append(back, card)
+	}
+
+	reordered := make([]types.Card, 0, len(cards))
+	for _, card := range cards[:top] {
+		if !returned[card.Mnemonic] {
+			reordered = append(reordered, card)
+		}
+	}
+	newTop := len(reordered)
+	reordered = append(append(reordered, cards[top:]...), back...)
+	for i, entry := range entries {
+		if entry.Position != len(reordered)-len(back)+i {
+			return nil, 0, fmt.Errorf("%w: %s reshuffled to position %d", ErrAuditMismatch, entry.Card, entry.Position)
+		}
+	}
+	return reordered, newTop, nil
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// dealHoldemHand deals two players and a full board with burns
+func dealHoldemHand(t *testing.T, deck *Deck) {
+	t.Helper()
+
+	for _, seat := range []int{1, 2} {
+		if _, err := deck.DealHole(seat, 2); err != nil {
+			t.Fatalf("DealHole failed: %v", err)
+		}
+	}
+	for _, amount := range []int{3, 1, 1} {
+		if _, err := deck.Burn(); err != nil {
+			t.Fatalf("Burn failed: %v", err)
+		}
+		if _, err := deck.DealBoard(amount); err != nil {
+			t.Fatalf("DealBoard failed: %v", err)
+		}
+	}
+}
+
+// TestDeck_Burn tests burning cards
+func TestDeck_Burn(t *testing.T) {
+	t.Run("should skip the burned card", func(t *testing.T) {
+		deck, _ := NewDeck("")
+
+		burned, err := deck.Burn()
+		if err != nil {
+			t.Fatalf("Burn failed: %v", err)
+		}
+		next, _ := deck.GetNext()
+
+		if burned.Mnemonic != "AC" || next.Mnemonic != "2C" {
+			t.Errorf("Expected AC burned and 2C next, got %s and %s", burned.Mnemonic, next.Mnemonic)
+		}
+		if deck.Remaining() != 50 {
+			t.Errorf("Expected 50 remaining cards, got %d", deck.Remaining())
+		}
+	})
+}
+
+// TestDeck_Audit tests the dealt-card audit trail
+func TestDeck_Audit(t *testing.T) {
+	t.Run("should record every card that leaves the deck", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("audit"))
+		dealHoldemHand(t, deck)
+
+		log := deck.GetAuditLog()
+		if len(log) != 12 {
+			t.Fatalf("Expected 12 entries, got %d", len(log))
+		}
+
+		expected := []Destination{
+			DestinationHole, DestinationHole, DestinationHole, DestinationHole,
+			DestinationBurn, DestinationBoard, DestinationBoard, DestinationBoard,
+			DestinationBurn, DestinationBoard, DestinationBurn, DestinationBoard,
+		}
+		for i, entry := range log {
+			if entry.Position != i {
+				t.Errorf("Entry %d: expected position %d, got %d", i, i, entry.Position)
+			}
+			if entry.Destination != expected[i] {
+				t.Errorf("Entry %d: expected %s, got %s", i, expected[i], entry.Destination)
+			}
+			if entry.Card != deck.cards[i].Mnemonic {
+				t.Errorf("Entry %d: expected %s, got %s", i, deck.cards[i].Mnemonic, entry.Card)
+			}
+		}
+		if log[2].Seat != 2 {
+			t.Errorf("Expected third card to go to seat 2, got %d", log[2].Seat)
+		}
+	})
+
+	t.Run("should verify after a JSON round trip", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("audit"))
+		dealHoldemHand(t, deck)
+
+		data, err := json.Marshal(deck.Audit())
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var audit DeckAudit
+		if err := json.Unmarshal(data, &audit); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		if err := VerifyAudit(audit); err != nil {
+			t.Errorf("Expected audit to verify, got %v", err)
+		}
+	})
+
+	t.Run("should detect a tampered card", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("audit"))
+		dealHoldemHand(t, deck)
+
+		audit := deck.Audit()
+		audit.Entries[5].Card = audit.Entries[6].Card
+
+		if err := VerifyAudit(audit); !errors.Is(err, ErrAuditMismatch) {
+			t.Errorf("Expected ErrAuditMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should detect a hash that does not match the deck", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("audit"))
+		dealHoldemHand(t, deck)
+
+		audit := deck.Audit()
+		other, _ := NewShuffledDeck([]byte("other"))
+		audit.Hash = other.GetHash()
+
+		if err := VerifyAudit(audit); !errors.Is(err, ErrAuditMismatch) {
+			t.Errorf("Expected ErrAuditMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should detect a log that does not reach the top of the deck", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("audit"))
+		dealHoldemHand(t, deck)
+
+		audit := deck.Audit()
+		audit.Entries = audit.Entries[:len(audit.Entries)-1]
+
+		if err := VerifyAudit(audit); !errors.Is(err, ErrAuditMismatch) {
+			t.Errorf("Expected ErrAuditMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should reset the log when shuffled", func(t *testing.T) {
+		deck, _ := NewDeck("")
+		_, _ = deck.Deal(3)
+		deck.Shuffle([]byte("again"))
+
+		if len(deck.GetAuditLog()) != 0 {
+			t.Errorf("Expected empty audit log, got %d entries", len(deck.GetAuditLog()))
+		}
+	})
+}
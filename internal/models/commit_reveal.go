@@ -0,0 +1,247 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Errors returned by the commit–reveal shuffle protocol
+var (
+	ErrNoParticipants       = errors.New("shuffle requires at least one participant")
+	ErrDuplicateParticipant = errors.New("duplicate participant")
+	ErrUnknownParticipant   = errors.New("unknown participant")
+	ErrAlreadyCommitted     = errors.New("participant has already committed")
+	ErrAlreadyRevealed      = errors.New("participant has already revealed")
+	ErrMissingCommitment    = errors.New("missing commitment")
+	ErrMissingReveal        = errors.New("missing reveal")
+	ErrRevealMismatch       = errors.New("revealed secret does not match commitment")
+	ErrLateReveal           = errors.New("reveal received after the deck was dealt")
+	ErrDeckMismatch         = errors.New("deck does not match revealed secrets")
+)
+
+// ShuffleSession runs the commit–reveal protocol that seeds a verifiable deck.
+//
+// Every participant first commits to SHA256(secret). Once all commitments are
+// in, participants reveal their secrets. When every secret has been revealed
+// the secrets are combined into a seed and the deck is shuffled from it with
+// Deck.Shuffle. Nobody can bias the deck without knowing every other secret
+// before committing to their own.
+type ShuffleSession struct {
+	participants []string
+	commitments  map[string]string
+	secrets      map[string][]byte
+	deck         *Deck
+}
+
+// ShuffleTranscript is the public record of a shuffle session.
+// It holds everything needed to check a dealt deck with VerifyShuffle.
+type ShuffleTranscript struct {
+	Commitments map[string]string `json:"commitments"`
+	Secrets     map[string]string `json:"secrets"` // hex encoded
+	Deck        string            `json:"deck"`
+	Hash        string            `json:"hash"`
+}
+
+// NewShuffleSession creates a session for the given participant addresses
+func NewShuffleSession(participants []string) (*ShuffleSession, error) {
+	if len(participants) == 0 {
+		return nil, ErrNoParticipants
+	}
+
+	seen := make(map[string]bool, len(participants))
+	for _, address := range participants {
+		if seen[address] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateParticipant, address)
+		}
+		seen[address] = true
+	}
+
+	return &ShuffleSession{
+		participants: append([]string(nil), participants...),
+		commitments:  make(map[string]string, len(participants)),
+		secrets:      make(map[string][]byte, len(participants)),
+	}, nil
+}
+
+// CommitSecret returns the commitment for a secret: hex(SHA256(secret))
+func CommitSecret(secret []byte) string {
+	hash := sha256.Sum256(secret)
+	return hex.EncodeToString(hash[:])
+}
+
+// Commit records a participant's commitment
+func (s *ShuffleSession) Commit(address string, commitment string) error {
+	if !s.isParticipant(address) {
+		return fmt.Errorf("%w: %s", ErrUnknownParticipant, address)
+	}
+	if _, ok := s.commitments[address]; ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyCommitted, address)
+	}
+
+	s.commitments[address] = strings.ToLower(commitment)
+	return nil
+}
+
+// Reveal records a participant's secret.
+// Reveals are only accepted once every participant has committed and before
+// the deck has been dealt.
+func (s *ShuffleSession) Reveal(address string, secret []byte) error {
+	if !s.isParticipant(address) {
+		return fmt.Errorf("%w: %s", ErrUnknownParticipant, address)
+	}
+	if s.deck != nil {
+		return fmt.Errorf("%w: %s", ErrLateReveal, address)
+	}
+	for _, participant := range s.participants {
+		if _, ok := s.commitments[participant]; !ok {
+			return fmt.Errorf("%w: %s", ErrMissingCommitment, participant)
+		}
+	}
+	if _, ok := s.secrets[address]; ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyRevealed, address)
+	}
+	if CommitSecret(secret) != s.commitments[address] {
+		return fmt.Errorf("%w: %s", ErrRevealMismatch, address)
+	}
+
+	s.secrets[address] = append([]byte(nil), secret...)
+	return nil
+}
+
+// Seed combines the revealed secrets into the shuffle seed
+func (s *ShuffleSession) Seed() ([]byte, error) {
+	for _, address := range s.participants {
+		if _, ok := s.secrets[address]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingReveal, address)
+		}
+	}
+	return CombineSecrets(s.secrets), nil
+}
+
+// Deal shuffles a standard deck from the combined seed and closes the session.
+// Calling Deal again returns the same deck.
+func (s *ShuffleSession) Deal() (*Deck, error) {
+	if s.deck != nil {
+		return s.deck, nil
+	}
+
+	seed, err := s.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	deck, err := NewShuffledDeck(seed)
+	if err != nil {
+		return nil, err
+	}
+	s.deck = deck
+	return deck, nil
+}
+
+// Transcript returns the public record of the session.
+// The deck fields are empty until the deck has been dealt.
+func (s *ShuffleSession) Transcript() ShuffleTranscript {
+	transcript := ShuffleTranscript{
+		Commitments: make(map[string]string, len(s.commitments)),
+		Secrets:     make(map[string]string, len(s.secrets)),
+	}
+	for address, commitment := range s.commitments {
+		transcript.Commitments[address] = commitment
+	}
+	for address, secret := range s.secrets {
+		transcript.Secrets[address] = hex.EncodeToString(secret)
+	}
+	if s.deck != nil {
+		transcript.Deck = s.deck.ToString()
+		transcript.Hash = s.deck.GetHash()
+	}
+	return transcript
+}
+
+// isParticipant reports whether the address takes part in the session
+func (s *ShuffleSession) isParticipant(address string) bool {
+	for _, participant := range s.participants {
+		if participant == address {
+			return true
+		}
+	}
+	return false
+}
+
+// CombineSecrets derives the shuffle seed from revealed secrets.
+//
+// The seed is SHA256 over every secret in ascending address order, each
+// prefixed with the address and the secret length as big-endian uint32s so
+// the encoding is unambiguous. The result does not depend on reveal order.
+func CombineSecrets(secrets map[string][]byte) []byte {
+	addresses := make([]string, 0, len(secrets))
+	for address := range secrets {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	h := sha256.New()
+	var length [4]byte
+	for _, address := range addresses {
+		binary.BigEndian.PutUint32(length[:], uint32(len(address)))
+		h.Write(length[:])
+		h.Write([]byte(address))
+
+		binary.BigEndian.PutUint32(length[:], uint32(len(secrets[address])))
+		h.Write(length[:])
+		h.Write(secrets[address])
+	}
+	return h.Sum(nil)
+}
+
+// VerifyShuffle checks that a published deck was derived from the revealed
+// secrets of a shuffle session. The deck string may carry a position marker
+// anywhere; only the card order is compared.
+func VerifyShuffle(transcript ShuffleTranscript) error {
+	if len(transcript.Commitments) == 0 {
+		return ErrNoParticipants
+	}
+
+	secrets := make(map[string][]byte, len(transcript.Commitments))
+	for address, commitment := range transcript.Commitments {
+		encoded, ok := transcript.Secrets[address]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrMissingReveal, address)
+		}
+		secret, err := hex.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("invalid secret for %s: %w", address, err)
+		}
+		if CommitSecret(secret) != strings.ToLower(commitment) {
+			return fmt.Errorf("%w: %s", ErrRevealMismatch, address)
+		}
+		secrets[address] = secret
+	}
+	for address := range transcript.Secrets {
+		if _, ok := transcript.Commitments[address]; !ok {
+			return fmt.Errorf("%w: %s", ErrMissingCommitment, address)
+		}
+	}
+
+	published, err := NewDeck(transcript.Deck)
+	if err != nil {
+		return fmt.Errorf("invalid published deck: %w", err)
+	}
+	if published.GetHash() != transcript.Hash {
+		return fmt.Errorf("%w: published hash does not match deck", ErrDeckMismatch)
+	}
+
+	expected, err := NewShuffledDeck(CombineSecrets(secrets))
+	if err != nil {
+		return err
+	}
+	if expected.GetHash() != transcript.Hash {
+		return fmt.Errorf("%w: expected hash %s, got %s", ErrDeckMismatch, expected.GetHash(), transcript.Hash)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+// newRevealedSession creates a session where every participant has committed and revealed
+func newRevealedSession(t *testing.T, secrets map[string][]byte, order []string) *ShuffleSession {
+	t.Helper()
+
+	session, err := NewShuffleSession(order)
+	if err != nil {
+		t.Fatalf("NewShuffleSession failed: %v", err)
+	}
+	for _, address := range order {
+		if err := session.Commit(address, CommitSecret(secrets[address])); err != nil {
+			t.Fatalf("Commit failed: %v", err)
+		}
+	}
+	for _, address := range order {
+		if err := session.Reveal(address, secrets[address]); err != nil {
+			t.Fatalf("Reveal failed: %v", err)
+		}
+	}
+	return session
+}
+
+// TestShuffleSession tests the commit–reveal shuffle protocol
+func TestShuffleSession(t *testing.T) {
+	secrets := map[string][]byte{
+		"0x1": []byte("alice-secret"),
+		"0x2": []byte("bob-secret"),
+		"0x3": []byte("carol-secret"),
+	}
+	players := []string{"0x1", "0x2", "0x3"}
+
+	t.Run("should deal a deck seeded by all secrets", func(t *testing.T) {
+		session := newRevealedSession(t, secrets, players)
+
+		deck, err := session.Deal()
+		if err != nil {
+			t.Fatalf("Deal failed: %v", err)
+		}
+
+		expected, _ := NewShuffledDeck(CombineSecrets(secrets))
+		if deck.GetHash() != expected.GetHash() {
+			t.Error("Expected deck to be shuffled from the combined secrets")
+		}
+	})
+
+	t.Run("should not depend on reveal order", func(t *testing.T) {
+		deck1, _ := newRevealedSession(t, secrets, players).Deal()
+		deck2, _ := newRevealedSession(t, secrets, []string{"0x3", "0x1", "0x2"}).Deal()
+
+		if deck1.GetHash() != deck2.GetHash() {
+			t.Error("Expected the same deck regardless of participant order")
+		}
+	})
+
+	t.Run("should reject a reveal that does not match the commitment", func(t *testing.T) {
+		session, _ := NewShuffleSession(players)
+		for _, address := range players {
+			_ = session.Commit(address, CommitSecret(secrets[address]))
+		}
+
+		err := session.Reveal("0x1", []byte("not-the-secret"))
+		if !errors.Is(err, ErrRevealMismatch) {
+			t.Errorf("Expected ErrRevealMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should reject reveals before all commitments", func(t *testing.T) {
+		session, _ := NewShuffleSession(players)
+		_ = session.Commit("0x1", CommitSecret(secrets["0x1"]))
+
+		err := session.Reveal("0x1", secrets["0x1"])
+		if !errors.Is(err, ErrMissingCommitment) {
+			t.Errorf("Expected ErrMissingCommitment, got %v", err)
+		}
+	})
+
+	t.Run("should not deal with a missing reveal", func(t *testing.T) {
+		session, _ := NewShuffleSession(players)
+		for _, address := range players {
+			_ = session.Commit(address, CommitSecret(secrets[address]))
+		}
+		_ = session.Reveal("0x1", secrets["0x1"])
+		_ = session.Reveal("0x2", secrets["0x2"])
+
+		if _, err := session.Deal(); !errors.Is(err, ErrMissingReveal) {
+			t.Errorf("Expected ErrMissingReveal, got %v", err)
+		}
+	})
+
+	t.Run("should reject reveals after the deal", func(t *testing.T) {
+		session, _ := NewShuffleSession([]string{"0x1", "0x2"})
+		_ = session.Commit("0x1", CommitSecret(secrets["0x1"]))
+		_ = session.Commit("0x2", CommitSecret(secrets["0x2"]))
+		_ = session.Reveal("0x1", secrets["0x1"])
+		_ = session.Reveal("0x2", secrets["0x2"])
+		if _, err := session.Deal(); err != nil {
+			t.Fatalf("Deal failed: %v", err)
+		}
+
+		if err := session.Reveal("0x2", secrets["0x2"]); !errors.Is(err, ErrLateReveal) {
+			t.Errorf("Expected ErrLateReveal, got %v", err)
+		}
+	})
+
+	t.Run("should reject unknown and duplicate participants", func(t *testing.T) {
+		if _, err := NewShuffleSession([]string{"0x1", "0x1"}); !errors.Is(err, ErrDuplicateParticipant) {
+			t.Errorf("Expected ErrDuplicateParticipant, got %v", err)
+		}
+
+		session, _ := NewShuffleSession(players)
+		if err := session.Commit("0x9", "00"); !errors.Is(err, ErrUnknownParticipant) {
+			t.Errorf("Expected ErrUnknownParticipant, got %v", err)
+		}
+		_ = session.Commit("0x1", CommitSecret(secrets["0x1"]))
+		if err := session.Commit("0x1", CommitSecret(secrets["0x1"])); !errors.Is(err, ErrAlreadyCommitted) {
+			t.Errorf("Expected ErrAlreadyCommitted, got %v", err)
+		}
+	})
+}
+
+// TestVerifyShuffle tests post-hand verification of a shuffle transcript
+func TestVerifyShuffle(t *testing.T) {
+	secrets := map[string][]byte{
+		"0x1": []byte("alice-secret"),
+		"0x2": []byte("bob-secret"),
+	}
+	players := []string{"0x1", "0x2"}
+
+	t.Run("should verify a dealt deck after cards were drawn", func(t *testing.T) {
+		session := newRevealedSession(t, secrets, players)
+		deck, _ := session.Deal()
+		if _, err := deck.Deal(9); err != nil {
+			t.Fatalf("Deal failed: %v", err)
+		}
+
+		if err := VerifyShuffle(session.Transcript()); err != nil {
+			t.Errorf("Expected transcript to verify, got %v", err)
+		}
+	})
+
+	t.Run("should reject a stacked deck", func(t *testing.T) {
+		session := newRevealedSession(t, secrets, players)
+		_, _ = session.Deal()
+
+		transcript := session.Transcript()
+		stacked, _ := NewShuffledDeck([]byte("dealer-choice"))
+		transcript.Deck = stacked.ToString()
+		transcript.Hash = stacked.GetHash()
+
+		if err := VerifyShuffle(transcript); !errors.Is(err, ErrDeckMismatch) {
+			t.Errorf("Expected ErrDeckMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should reject a hash that does not match the deck", func(t *testing.T) {
+		session := newRevealedSession(t, secrets, players)
+		_, _ = session.Deal()
+
+		transcript := session.Transcript()
+		transcript.Hash = CommitSecret([]byte("other"))
+
+		if err := VerifyShuffle(transcript); !errors.Is(err, ErrDeckMismatch) {
+			t.Errorf("Expected ErrDeckMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should reject missing and mismatched reveals", func(t *testing.T) {
+		session := newRevealedSession(t, secrets, players)
+		_, _ = session.Deal()
+
+		missing := session.Transcript()
+		delete(missing.Secrets, "0x2")
+		if err := VerifyShuffle(missing); !errors.Is(err, ErrMissingReveal) {
+			t.Errorf("Expected ErrMissingReveal, got %v", err)
+		}
+
+		mismatched := session.Transcript()
+		mismatched.Secrets["0x2"] = "deadbeef"
+		if err := VerifyShuffle(mismatched); !errors.Is(err, ErrRevealMismatch) {
+			t.Errorf("Expected ErrRevealMismatch, got %v", err)
+		}
+	})
+}
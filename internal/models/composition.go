@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// ErrUnexpectedCard is returned when a deck string contains a card that is
+// not part of the deck's composition
+var ErrUnexpectedCard = errors.New("card not in deck composition")
+
+// DeckComposition is the set of cards a deck is built from.
+// Cards are kept in the deck's unshuffled order.
+type DeckComposition struct {
+	name  string
+	cards []types.Card
+}
+
+var (
+	// StandardComposition is the standard 52-card deck
+	StandardComposition = newRankComposition("standard", 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13)
+
+	// ShortDeckComposition is the 36-card short deck (6 through A) used by Short Deck Hold'em
+	ShortDeckComposition = newRankComposition("short-deck", 1, 6, 7, 8, 9, 10, 11, 12, 13)
+)
+
+// newRankComposition builds a composition with the given ranks in every suit,
+// ordered by suit (clubs, diamonds, hearts, spades) then by rank
+func newRankComposition(name string, ranks ...int) DeckComposition {
+	cards := make([]types.Card, 0, 4*len(ranks))
+	for suit := types.SuitClubs; suit <= types.SuitSpades; suit++ {
+		for _, rank := range ranks {
+			cards = append(cards, cardFromValue(13*(int(suit)-1)+(rank-1)))
+		}
+	}
+	return DeckComposition{name: name, cards: cards}
+}
+
+// NewCustomComposition creates a composition from an arbitrary set of cards,
+// kept in the order given. Every card must be unique.
+func NewCustomComposition(mnemonics []string) (DeckComposition, error) {
+	if len(mnemonics) == 0 {
+		return DeckComposition{}, errors.New("composition must contain at least one card")
+	}
+
+	seen := make(map[int]bool, len(mnemonics))
+	cards := make([]types.Card, 0, len(mnemonics))
+	for i, mnemonic := range mnemonics {
+		card, err := FromString(mnemonic)
+		if err != nil {
+			return DeckComposition{}, &DeckError{Err: err, Position: i, Card: mnemonic}
+		}
+		if seen[card.Value] {
+			return DeckComposition{}, &DeckError{Err: ErrDuplicateCard, Position: i, Card: card.Mnemonic}
+		}
+		seen[card.Value] = true
+		cards = append(cards, cardFromValue(card.Value))
+	}
+	return DeckComposition{name: "custom", cards: cards}, nil
+}
+
+// CompositionForVariant returns the composition a game variant is played with
+func CompositionForVariant(variant types.GameVariant) DeckComposition {
+	switch variant {
+	case types.VariantShortDeck:
+		return ShortDeckComposition
+	default:
+		return StandardComposition
+	}
+}
+
+// compositionForSize returns the built-in composition with the given number of cards
+func compositionForSize(size int) DeckComposition {
+	if size == len(ShortDeckComposition.cards) {
+		return ShortDeckComposition
+	}
+	return StandardComposition
+}
+
+// Name returns the composition name ("standard", "short-deck" or "custom")
+func (c DeckComposition) Name() string {
+	return c.name
+}
+
+// Size returns the number of cards in the composition
+func (c DeckComposition) Size() int {
+	return len(c.cards)
+}
+
+// Contains reports whether the card is part of the composition
+func (c DeckComposition) Contains(card types.Card) bool {
+	for _, candidate := range c.cards {
+		if candidate.Value == card.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// Cards returns a copy of the cards in unshuffled order
+func (c DeckComposition) Cards() []types.Card {
+	return append([]types.Card(nil), c.cards...)
+}
+
+// NewDeckWithComposition creates a deck of the given composition from an
+// optional deck string. An empty string yields the composition in its
+// unshuffled order; otherwise the string must contain every card of the
+// composition exactly once.
+func NewDeckWithComposition(deckStr string, composition DeckComposition) (*Deck, error) {
+	if composition.Size() == 0 {
+		return nil, fmt.Errorf("deck composition %q has no cards", composition.name)
+	}
+	return newDeck(deckStr, composition)
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// TestDeckComposition tests short-deck and custom deck compositions
+func TestDeckComposition(t *testing.T) {
+	t.Run("should build a 36-card short deck from 6 through A", func(t *testing.T) {
+		deck, err := NewDeckWithComposition("", ShortDeckComposition)
+		if err != nil {
+			t.Fatalf("NewDeckWithComposition failed: %v", err)
+		}
+
+		if len(deck.cards) != 36 {
+			t.Fatalf("Expected 36 cards, got %d", len(deck.cards))
+		}
+		for _, card := range deck.cards {
+			if card.Rank != 1 && card.Rank < 6 {
+				t.Errorf("Unexpected card %s in short deck", card.Mnemonic)
+			}
+		}
+		if deck.cards[0].Mnemonic != "AC" || deck.cards[1].Mnemonic != "6C" || deck.cards[35].Mnemonic != "KS" {
+			t.Errorf("Unexpected short deck order: %s", deck.ToString())
+		}
+	})
+
+	t.Run("should parse a 36-card string as a short deck", func(t *testing.T) {
+		shuffled, _ := NewDeckWithComposition("", ShortDeckComposition)
+		shuffled.Shuffle([]byte("short"))
+
+		deck, err := NewDeck(shuffled.ToString())
+		if err != nil {
+			t.Fatalf("NewDeck failed: %v", err)
+		}
+		if deck.GetComposition().Name() != "short-deck" {
+			t.Errorf("Expected short-deck composition, got %s", deck.GetComposition().Name())
+		}
+		if deck.ToString() != shuffled.ToString() {
+			t.Error("Expected string round trip to preserve the deck")
+		}
+		if deck.GetHash() != shuffled.GetHash() {
+			t.Error("Expected string round trip to preserve the hash")
+		}
+	})
+
+	t.Run("should reject cards outside the composition", func(t *testing.T) {
+		deck, _ := NewDeckWithComposition("", ShortDeckComposition)
+		mnemonic := deck.ToString()
+		invalid := "[2C]" + mnemonic[len("[AC]"):]
+
+		_, err := NewDeck(invalid)
+		if !errors.Is(err, ErrUnexpectedCard) {
+			t.Errorf("Expected ErrUnexpectedCard, got %v", err)
+		}
+	})
+
+	t.Run("should support custom compositions", func(t *testing.T) {
+		composition, err := NewCustomComposition([]string{"AS", "KS", "QS", "JS", "TS"})
+		if err != nil {
+			t.Fatalf("NewCustomComposition failed: %v", err)
+		}
+
+		deck, err := NewDeckWithComposition("TS-JS-[QS]-KS-AS", composition)
+		if err != nil {
+			t.Fatalf("NewDeckWithComposition failed: %v", err)
+		}
+		if deck.Remaining() != 3 {
+			t.Errorf("Expected 3 remaining cards, got %d", deck.Remaining())
+		}
+		if deck.ToString() != "TS-JS-[QS]-KS-AS" {
+			t.Errorf("Unexpected string: %s", deck.ToString())
+		}
+
+		if _, err := NewDeckWithComposition("TS-JS-QS-KS", composition); !errors.Is(err, ErrMissingCard) {
+			t.Errorf("Expected ErrMissingCard, got %v", err)
+		}
+	})
+
+	t.Run("should reject duplicate cards in a custom composition", func(t *testing.T) {
+		if _, err := NewCustomComposition([]string{"AS", "AS"}); !errors.Is(err, ErrDuplicateCard) {
+			t.Errorf("Expected ErrDuplicateCard, got %v", err)
+		}
+	})
+
+	t.Run("should select the composition from the game variant", func(t *testing.T) {
+		if CompositionForVariant(types.VariantShortDeck).Size() != 36 {
+			t.Error("Expected short deck variant to use 36 cards")
+		}
+		if CompositionForVariant(types.VariantTexasHoldem).Size() != 52 {
+			t.Error("Expected Texas Hold'em to use 52 cards")
+		}
+	})
+}
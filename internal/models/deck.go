@@ -12,76 +12,175 @@ import (
 	"github.com/block52/go-pvm/internal/types"
 )
 
+// Errors returned when parsing cards and deck strings
+var (
+	ErrInvalidMnemonic = errors.New("invalid card mnemonic")
+	ErrInvalidRank     = errors.New("invalid rank")
+	ErrDuplicateCard   = errors.New("duplicate card")
+	ErrMissingCard     = errors.New("missing card")
+	ErrMultipleMarkers = errors.New("multiple position markers")
+)
+
+// DeckError reports which card or position of a deck string is invalid.
+// Err is one of the parsing errors above and can be matched with errors.Is.
+type DeckError struct {
+	Err      error
+	Position int    // Index in the deck string, or -1 if not tied to a position
+	Card     string // Offending card mnemonic
+}
+
+// Error implements the error interface
+func (e *DeckError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("invalid deck: %v %s", e.Err, e.Card)
+	}
+	return fmt.Sprintf("invalid deck at position %d (%s): %v", e.Position, e.Card, e.Err)
+}
+
+// Unwrap returns the underlying parsing error
+func (e *DeckError) Unwrap() error {
+	return e.Err
+}
+
 // Deck represents a deck of playing cards
 // Matches TypeScript Deck implementation from pvm/ts/src/models/deck.ts
 type Deck struct {
-	cards []types.Card
-	hash  string
-	top   int // Current position in deck (like TypeScript 'top')
+	cards       []types.Card
+	hash        string
+	top         int // Current position in deck (like TypeScript 'top')
+	composition DeckComposition
+	audit       []AuditEntry
+	start       []types.Card // Order when the audit log began, kept once a reshuffle moves cards
+	startTop    int
 }
 
 // NewDeck creates a new deck from an optional deck string
 // If deckStr is empty, creates a standard 52-card deck
 // Matches TypeScript constructor
+//
+// The composition is chosen from the number of cards in the string: 36 cards
+// parse as a short deck, anything else as a standard deck. Use
+// NewDeckWithComposition for custom compositions.
+//
+// A deck string must contain every card exactly once and at most one
+// [position] marker; violations are reported as *DeckError.
 func NewDeck(deckStr string) (*Deck, error) {
+	// For backwards compatibility: treat empty strings as undefined (create standard deck)
+	deckStr = strings.TrimSpace(deckStr)
+
+	composition := StandardComposition
+	if deckStr != "" {
+		composition = compositionForSize(strings.Count(deckStr, "-") + 1)
+	}
+	return newDeck(deckStr, composition)
+}
+
+// newDeck creates a deck of the given composition from an optional deck string
+func newDeck(deckStr string, composition DeckComposition) (*Deck, error) {
 	d := &Deck{
-		cards: make([]types.Card, 0, 52),
-		hash:  "",
-		top:   0,
+		cards:       make([]types.Card, 0, composition.Size()),
+		hash:        "",
+		top:         0,
+		composition: composition,
 	}
 
-	// For backwards compatibility: treat empty strings as undefined (create standard deck)
 	deckStr = strings.TrimSpace(deckStr)
 
 	if deckStr != "" {
-		// Parse deck from string format like "AS-2C-3D-[4H]-5S-..."
-		mnemonics := strings.Split(deckStr, "-")
-		if len(mnemonics) != 52 {
-			return nil, errors.New("deck must contain 52 cards")
+		if err := d.parse(deckStr); err != nil {
+			return nil, err
 		}
+	} else {
+		d.cards = composition.Cards()
+	}
 
-		for i, mnemonic := range mnemonics {
-			// Check if this is the current top position (marked with brackets)
-			if strings.HasPrefix(mnemonic, "[") && strings.HasSuffix(mnemonic, "]") {
-				mnemonic = strings.Trim(mnemonic, "[]")
-				d.top = i
-			}
+	d.createHash()
+	return d, nil
+}
 
-			card, err := FromString(mnemonic)
-			if err != nil {
-				return nil, fmt.Errorf("invalid card at position %d: %w", i, err)
+// parse loads cards and the top position from a string like "AS-2C-3D-[4H]-5S-..."
+func (d *Deck) parse(deckStr string) error {
+	mnemonics := strings.Split(deckStr, "-")
+	marker := -1
+	positions := make(map[int]int, len(mnemonics)) // card value -> first position
+
+	for i, mnemonic := range mnemonics {
+		// Check if this is the current top position (marked with brackets)
+		if strings.HasPrefix(mnemonic, "[") && strings.HasSuffix(mnemonic, "]") {
+			mnemonic = strings.TrimSuffix(strings.TrimPrefix(mnemonic, "["), "]")
+			if marker >= 0 {
+				return &DeckError{Err: ErrMultipleMarkers, Position: i, Card: mnemonic}
 			}
-			d.cards = append(d.cards, card)
+			marker = i
 		}
-	} else {
-		d.initStandard52()
+
+		card, err := FromString(mnemonic)
+		if err != nil {
+			return &DeckError{Err: err, Position: i, Card: mnemonic}
+		}
+		if !d.composition.Contains(card) {
+			return &DeckError{Err: ErrUnexpectedCard, Position: i, Card: card.Mnemonic}
+		}
+		if _, ok := positions[card.Value]; ok {
+			return &DeckError{Err: ErrDuplicateCard, Position: i, Card: card.Mnemonic}
+		}
+		positions[card.Value] = i
+		d.cards = append(d.cards, card)
 	}
 
-	d.createHash()
-	return d, nil
+	for _, card := range d.composition.cards {
+		if _, ok := positions[card.Value]; !ok {
+			return &DeckError{Err: ErrMissingCard, Position: -1, Card: card.Mnemonic}
+		}
+	}
+
+	if marker >= 0 {
+		d.top = marker
+	}
+	return nil
+}
+
+// GetComposition returns the composition the deck was built from
+func (d *Deck) GetComposition() DeckComposition {
+	return d.composition
 }
 
 // GetNext returns the next card from the deck
 // Matches TypeScript getNext()
 func (d *Deck) GetNext() (types.Card, error) {
-	if d.top >= len(d.cards) {
-		return types.Card{}, errors.New("no more cards in deck")
-	}
-	card := d.cards[d.top]
-	d.top++
-	return card, nil
+	return d.draw(DestinationUnassigned, 0)
 }
 
 // Deal deals a specified number of cards
 // Matches TypeScript deal(amount)
 func (d *Deck) Deal(amount int) ([]types.Card, error) {
+	return d.dealTo(amount, DestinationUnassigned, 0)
+}
+
+// DealHole deals hole cards to the player in the given seat
+func (d *Deck) DealHole(seat int, amount int) ([]types.Card, error) {
+	return d.dealTo(amount, DestinationHole, seat)
+}
+
+// DealBoard deals community cards to the board
+func (d *Deck) DealBoard(amount int) ([]types.Card, error) {
+	return d.dealTo(amount, DestinationBoard, 0)
+}
+
+// Burn discards the next card face down, as before the flop, turn and river
+func (d *Deck) Burn() (types.Card, error) {
+	return d.draw(DestinationBurn, 0)
+}
+
+// dealTo deals amount cards to a single destination
+func (d *Deck) dealTo(amount int, destination Destination, seat int) ([]types.Card, error) {
 	if d.top+amount > len(d.cards) {
 		return nil, errors.New("not enough cards in deck")
 	}
 
 	cards := make([]types.Card, amount)
 	for i := 0; i < amount; i++ {
-		card, err := d.GetNext()
+		card, err := d.draw(destination, seat)
 		if err != nil {
 			return nil, err
 		}
@@ -90,6 +189,22 @@ func (d *Deck) Deal(amount int) ([]types.Card, error) {
 	return cards, nil
 }
 
+// draw takes the top card and records where it went in the audit log
+func (d *Deck) draw(destination Destination, seat int) (types.Card, error) {
+	if d.top >= len(d.cards) {
+		return types.Card{}, errors.New("no more cards in deck")
+	}
+	card := d.cards[d.top]
+	d.audit = append(d.audit, AuditEntry{
+		Position:    d.top,
+		Card:        card.Mnemonic,
+		Destination: destination,
+		Seat:        seat,
+	})
+	d.top++
+	return card, nil
+}
+
 // ToString serializes the deck to string format with position marker
 // Matches TypeScript toString()
 func (d *Deck) ToString() string {
@@ -120,8 +235,11 @@ func (d *Deck) Remaining() int {
 }
 
 // DeckJSON represents the JSON structure for a deck
+// Matches the deck shape used by @block52/poker-vm-sdk
 type DeckJSON struct {
 	Cards []types.Card `json:"cards"`
+	Top   int          `json:"top"`
+	Hash  string       `json:"hash"`
 }
 
 // ToJson serializes the deck to a JSON-compatible structure
@@ -129,6 +247,8 @@ type DeckJSON struct {
 func (d *Deck) ToJson() DeckJSON {
 	return DeckJSON{
 		Cards: d.cards,
+		Top:   d.top,
+		Hash:  d.hash,
 	}
 }
 
@@ -144,26 +264,8 @@ func (d *Deck) createHash() {
 	d.hash = hex.EncodeToString(hash[:])
 }
 
-// initStandard52 initializes a standard 52-card deck
-// Matches TypeScript initStandard52()
-func (d *Deck) initStandard52() {
-	d.cards = make([]types.Card, 0, 52)
-
-	// Iterate through suits: CLUBS=1, DIAMONDS=2, HEARTS=3, SPADES=4
-	for suit := types.SuitClubs; suit <= types.SuitSpades; suit++ {
-		// Iterate through ranks: 1=Ace, 2-9=numbers, 10=Ten, 11=Jack, 12=Queen, 13=King
-		for rank := 1; rank <= 13; rank++ {
-			mnemonic := GetCardMnemonic(suit, rank)
-			value := 13*(int(suit)-1) + (rank - 1)
-			d.cards = append(d.cards, types.Card{
-				Suit:     suit,
-				Rank:     rank,
-				Value:    value,
-				Mnemonic: mnemonic,
-			})
-		}
-	}
-}
+// mnemonicPattern matches a rank followed by a suit character
+var mnemonicPattern = regexp.MustCompile(`^([AJQKT]|[0-9]+)([CDHS])$`)
 
 // GetCardMnemonic generates the mnemonic string for a card
 // Matches TypeScript getCardMnemonic()
@@ -201,15 +303,25 @@ func GetCardMnemonic(suit types.Suit, rank int) string {
 	return rankStr + suitStr
 }
 
+// cardFromValue builds the card with the given value (0-51)
+// Callers only pass values from a known composition.
+func cardFromValue(value int) types.Card {
+	card, _ := types.CardFromValue(value)
+	return card
+}
+
 // FromString parses a card mnemonic string into a Card
 // Matches TypeScript fromString()
+//
+// Ranks are A, 2-9, T (or 10), J, Q and K; anything else such as "14S" or
+// "0H" is rejected with ErrInvalidRank. "10H" is read as "TH" so a deck hashes
+// the same whichever spelling it was parsed from.
 func FromString(mnemonic string) (types.Card, error) {
 	// Match pattern like "AS", "2C", "10H", "KD"
-	re := regexp.MustCompile(`^([AJQKTajqkt]|[0-9]+)([CDHS])$`)
-	matches := re.FindStringSubmatch(strings.ToUpper(mnemonic))
+	matches := mnemonicPattern.FindStringSubmatch(strings.ToUpper(mnemonic))
 
 	if matches == nil {
-		return types.Card{}, fmt.Errorf("invalid card mnemonic: %s", mnemonic)
+		return types.Card{}, fmt.Errorf("%w: %s", ErrInvalidMnemonic, mnemonic)
 	}
 
 	rankStr := matches[1]
@@ -231,8 +343,8 @@ func FromString(mnemonic string) (types.Card, error) {
 	default:
 		var err error
 		rank, err = strconv.Atoi(rankStr)
-		if err != nil {
-			return types.Card{}, fmt.Errorf("invalid rank: %s", rankStr)
+		if err != nil || rank < 2 || rank > 10 || rankStr[0] == '0' {
+			return types.Card{}, fmt.Errorf("%w: %s", ErrInvalidRank, rankStr)
 		}
 	}
 
@@ -258,6 +370,6 @@ func FromString(mnemonic string) (types.Card, error) {
 		Suit:     suit,
 		Rank:     rank,
 		Value:    value,
-		Mnemonic: strings.ToUpper(mnemonic), // Use original mnemonic for consistency
+		Mnemonic: GetCardMnemonic(suit, rank),
 	}, nil
 }
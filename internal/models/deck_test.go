@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/block52/go-pvm/internal/types"
@@ -255,3 +257,67 @@ func TestDeck_HashGeneration(t *testing.T) {
 		}
 	})
 }
+
+// TestDeck_Validation tests strict validation of deck strings
+func TestDeck_Validation(t *testing.T) {
+	standard := []string{
+		"AC", "2C", "3C", "4C", "5C", "6C", "7C", "8C", "9C", "TC", "JC", "QC", "KC",
+		"AD", "2D", "3D", "4D", "5D", "6D", "7D", "8D", "9D", "TD", "JD", "QD", "KD",
+		"AH", "2H", "3H", "4H", "5H", "6H", "7H", "8H", "9H", "TH", "JH", "QH", "KH",
+		"AS", "2S", "3S", "4S", "5S", "6S", "7S", "8S", "9S", "TS", "JS", "QS", "KS",
+	}
+
+	// withCards returns the standard deck string with the given positions replaced
+	withCards := func(replacements map[int]string) string {
+		mnemonics := append([]string(nil), standard...)
+		for position, mnemonic := range replacements {
+			mnemonics[position] = mnemonic
+		}
+		return strings.Join(mnemonics, "-")
+	}
+
+	tests := []struct {
+		name     string
+		deckStr  string
+		err      error
+		position int
+		card     string
+	}{
+		{"duplicate card", withCards(map[int]string{25: "AS"}), ErrDuplicateCard, 39, "AS"},
+		{"multiple markers", withCards(map[int]string{0: "[AC]", 5: "[6C]"}), ErrMultipleMarkers, 5, "6C"},
+		{"rank above king", withCards(map[int]string{3: "14C"}), ErrInvalidRank, 3, "14C"},
+		{"rank zero", withCards(map[int]string{28: "0H"}), ErrInvalidRank, 28, "0H"},
+		{"unknown mnemonic", withCards(map[int]string{10: "XX"}), ErrInvalidMnemonic, 10, "XX"},
+		{"missing card", strings.Join(standard[:51], "-"), ErrMissingCard, -1, "KS"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			_, err := NewDeck(tt.deckStr)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected %v, got %v", tt.err, err)
+			}
+
+			var deckErr *DeckError
+			if !errors.As(err, &deckErr) {
+				t.Fatalf("Expected *DeckError, got %T", err)
+			}
+			if deckErr.Position != tt.position {
+				t.Errorf("Expected position %d, got %d", tt.position, deckErr.Position)
+			}
+			if deckErr.Card != tt.card {
+				t.Errorf("Expected card %s, got %s", tt.card, deckErr.Card)
+			}
+		})
+	}
+
+	t.Run("should accept a single marker and numeric ten", func(t *testing.T) {
+		deck, err := NewDeck(withCards(map[int]string{9: "10C", 20: "[8D]"}))
+		if err != nil {
+			t.Fatalf("NewDeck failed: %v", err)
+		}
+		if deck.GetTop() != 20 {
+			t.Errorf("Expected top to be 20, got %d", deck.GetTop())
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// MarshalBinary encodes the deck compactly: one byte for the top position
+// followed by one byte per card value, in deck order. A standard deck takes
+// 53 bytes instead of the ~200 bytes of ToString. The audit log is not
+// included.
+func (d *Deck) MarshalBinary() ([]byte, error) {
+	if d.top > 255 {
+		return nil, fmt.Errorf("deck position %d does not fit in a byte", d.top)
+	}
+
+	data := make([]byte, 0, len(d.cards)+1)
+	data = append(data, byte(d.top))
+	for _, card := range d.cards {
+		encoded, err := card.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, encoded...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a deck encoded by MarshalBinary.
+// If the deck already has a composition (e.g. from NewDeckWithComposition)
+// the cards must match it; otherwise the composition is chosen from the
+// number of cards, as NewDeck does. The cards go through the same validation
+// as a deck string and the hash is recomputed.
+func (d *Deck) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("deck encoding must contain a position and at least one card")
+	}
+
+	top := int(data[0])
+	values := data[1:]
+	if top > len(values) {
+		return fmt.Errorf("deck position %d out of range for %d cards", top, len(values))
+	}
+
+	mnemonics := make([]string, len(values))
+	for i, value := range values {
+		var card types.Card
+		if err := card.UnmarshalBinary([]byte{value}); err != nil {
+			return &DeckError{Err: err, Position: i, Card: fmt.Sprintf("0x%02x", value)}
+		}
+		mnemonics[i] = card.Mnemonic
+	}
+
+	composition := d.composition
+	if composition.Size() == 0 {
+		composition = compositionForSize(len(values))
+	}
+
+	decoded, err := newDeck(strings.Join(mnemonics, "-"), composition)
+	if err != nil {
+		return err
+	}
+	decoded.top = top
+
+	*d = *decoded
+	return nil
+}
+
+// MarshalJSON encodes the deck as {"cards": [...], "top": n, "hash": "..."}
+func (d *Deck) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.ToJson())
+}
+
+// UnmarshalJSON rebuilds a deck from the JSON produced by MarshalJSON.
+// Cards are validated like a deck string and, when a hash is present, it
+// must match the decoded cards.
+func (d *Deck) UnmarshalJSON(data []byte) error {
+	var raw DeckJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Cards) == 0 {
+		return errors.New("deck JSON must contain cards")
+	}
+	if raw.Top < 0 || raw.Top > len(raw.Cards) {
+		return fmt.Errorf("deck position %d out of range for %d cards", raw.Top, len(raw.Cards))
+	}
+
+	mnemonics := make([]string, len(raw.Cards))
+	for i, card := range raw.Cards {
+		mnemonics[i] = card.Mnemonic
+	}
+
+	composition := d.composition
+	if composition.Size() == 0 {
+		composition = compositionForSize(len(raw.Cards))
+	}
+
+	decoded, err := newDeck(strings.Join(mnemonics, "-"), composition)
+	if err != nil {
+		return err
+	}
+	if raw.Hash != "" && raw.Hash != decoded.hash {
+		return fmt.Errorf("deck hash %s does not match cards (expected %s)", raw.Hash, decoded.hash)
+	}
+	decoded.top = raw.Top
+
+	*d = *decoded
+	return nil
+}
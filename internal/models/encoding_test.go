@@ -0,0 +1,181 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestDeck_Binary tests the compact binary encoding
+func TestDeck_Binary(t *testing.T) {
+	t.Run("should encode a standard deck in 53 bytes", func(t *testing.T) {
+		deck, _ := NewDeck("")
+
+		data, err := deck.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary failed: %v", err)
+		}
+		if len(data) != 53 {
+			t.Errorf("Expected 53 bytes, got %d", len(data))
+		}
+		if data[0] != 0 || data[1] != 0 || data[52] != 51 {
+			t.Errorf("Unexpected encoding: %v", data)
+		}
+	})
+
+	t.Run("should round trip with the string format", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("binary"))
+		_, _ = deck.Deal(7)
+
+		data, err := deck.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary failed: %v", err)
+		}
+
+		var decoded Deck
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		if decoded.ToString() != deck.ToString() {
+			t.Errorf("Expected %s, got %s", deck.ToString(), decoded.ToString())
+		}
+		if decoded.GetHash() != deck.GetHash() {
+			t.Error("Expected hash to survive the round trip")
+		}
+		if decoded.GetTop() != 7 {
+			t.Errorf("Expected top to be 7, got %d", decoded.GetTop())
+		}
+
+		fromString, _ := NewDeck(deck.ToString())
+		reencoded, _ := fromString.MarshalBinary()
+		if string(reencoded) != string(data) {
+			t.Error("Expected string-parsed deck to encode identically")
+		}
+	})
+
+	t.Run("should read a numeric ten as T", func(t *testing.T) {
+		standard, _ := NewDeck("")
+		deck, err := NewDeck(strings.Replace(standard.ToString(), "TH", "10H", 1))
+		if err != nil {
+			t.Fatalf("NewDeck failed: %v", err)
+		}
+
+		data, _ := deck.MarshalBinary()
+		var decoded Deck
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		if decoded.GetHash() != deck.GetHash() || deck.GetHash() != standard.GetHash() {
+			t.Errorf("Expected the hash to survive the round trip, got %s and %s", deck.GetHash(), decoded.GetHash())
+		}
+	})
+
+	t.Run("should round trip a short deck", func(t *testing.T) {
+		deck, _ := NewDeckWithComposition("", ShortDeckComposition)
+		deck.Shuffle([]byte("short"))
+
+		data, _ := deck.MarshalBinary()
+		var decoded Deck
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		if decoded.GetComposition().Name() != "short-deck" {
+			t.Errorf("Expected short-deck composition, got %s", decoded.GetComposition().Name())
+		}
+		if decoded.ToString() != deck.ToString() {
+			t.Errorf("Expected %s, got %s", deck.ToString(), decoded.ToString())
+		}
+	})
+
+	t.Run("should reject duplicate cards and bad values", func(t *testing.T) {
+		deck, _ := NewDeck("")
+		data, _ := deck.MarshalBinary()
+
+		duplicate := append([]byte(nil), data...)
+		duplicate[2] = duplicate[1]
+		var decoded Deck
+		if err := decoded.UnmarshalBinary(duplicate); !errors.Is(err, ErrDuplicateCard) {
+			t.Errorf("Expected ErrDuplicateCard, got %v", err)
+		}
+
+		invalid := append([]byte(nil), data...)
+		invalid[10] = 52
+		var deckErr *DeckError
+		if err := decoded.UnmarshalBinary(invalid); !errors.As(err, &deckErr) || deckErr.Position != 9 {
+			t.Errorf("Expected *DeckError at position 9, got %v", err)
+		}
+
+		outOfRange := append([]byte(nil), data...)
+		outOfRange[0] = 53
+		if err := decoded.UnmarshalBinary(outOfRange); err == nil {
+			t.Error("Expected error for out of range position")
+		}
+	})
+}
+
+// TestDeck_JSON tests the SDK-compatible JSON encoding
+func TestDeck_JSON(t *testing.T) {
+	t.Run("should include cards, top and hash", func(t *testing.T) {
+		deck, _ := NewDeck("")
+		_, _ = deck.Deal(2)
+
+		data, err := json.Marshal(deck)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if string(raw["top"]) != "2" {
+			t.Errorf("Expected top 2, got %s", raw["top"])
+		}
+		if string(raw["hash"]) != `"`+deck.GetHash()+`"` {
+			t.Errorf("Expected hash %s, got %s", deck.GetHash(), raw["hash"])
+		}
+
+		var cards []map[string]interface{}
+		if err := json.Unmarshal(raw["cards"], &cards); err != nil {
+			t.Fatalf("Unmarshal cards failed: %v", err)
+		}
+		if cards[0]["mnemonic"] != "AC" || cards[0]["suit"] != float64(1) || cards[0]["rank"] != float64(1) {
+			t.Errorf("Unexpected first card: %v", cards[0])
+		}
+	})
+
+	t.Run("should rebuild a full deck", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("json"))
+		_, _ = deck.Deal(4)
+
+		data, _ := json.Marshal(deck)
+		var decoded Deck
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		if decoded.ToString() != deck.ToString() {
+			t.Errorf("Expected %s, got %s", deck.ToString(), decoded.ToString())
+		}
+		if decoded.GetHash() != deck.GetHash() {
+			t.Error("Expected hash to survive the round trip")
+		}
+		next, _ := decoded.GetNext()
+		if next.Mnemonic != deck.cards[4].Mnemonic {
+			t.Errorf("Expected next card %s, got %s", deck.cards[4].Mnemonic, next.Mnemonic)
+		}
+	})
+
+	t.Run("should reject a mismatched hash", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("json"))
+		payload := deck.ToJson()
+		payload.Hash = "00"
+
+		data, _ := json.Marshal(payload)
+		var decoded Deck
+		if err := json.Unmarshal(data, &decoded); err == nil {
+			t.Error("Expected error for mismatched hash")
+		}
+	})
+}
@@ -0,0 +1,624 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// Errors returned by the mental poker encrypted deck
+var (
+	ErrInvalidGroup       = errors.New("modulus is not a safe prime")
+	ErrWrongPhase         = errors.New("action not allowed in the current phase")
+	ErrOutOfTurn          = errors.New("player is not next to encrypt")
+	ErrInvalidPermutation = errors.New("invalid permutation")
+	ErrInvalidKey         = errors.New("invalid key")
+	ErrKeyNotReleased     = errors.New("card key has not been released")
+	ErrInvalidPosition    = errors.New("invalid deck position")
+	ErrInvalidCiphertext  = errors.New("ciphertext does not decrypt to a card")
+	ErrTranscriptMismatch = errors.New("revealed keys do not reproduce the encrypted deck")
+)
+
+// rfc3526Group14 is the 2048-bit MODP safe prime from RFC 3526
+const rfc3526Group14 = "FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74" +
+	"020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F1437" +
+	"4FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED" +
+	"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF05" +
+	"98DA48361C55D39A69163FA8FD24CF5F83655D23DCA3AD961C62F356208552BB" +
+	"9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B" +
+	"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF695581718" +
+	"3995497CEA956AE515D2261898FA051015728E5A8AACAA68FFFFFFFFFFFFFFFF"
+
+// keyCheckBase is the public quadratic residue used to commit to card keys
+var keyCheckBase = big.NewInt(4)
+
+// SRAGroup is the group the commutative SRA cipher works in.
+//
+// Cards are encoded as quadratic residues modulo a safe prime p = 2q + 1, so
+// every plaintext and ciphertext lies in the subgroup of prime order q.
+// Encryption is m^e mod p and decryption is c^d mod p with e*d = 1 mod q.
+// Because exponentiation commutes, layers of encryption added by different
+// players can be removed in any order.
+type SRAGroup struct {
+	p *big.Int
+	q *big.Int
+}
+
+// SRAKey is a commutative encryption key pair
+type SRAKey struct {
+	E *big.Int // Encryption exponent
+	D *big.Int // Decryption exponent
+}
+
+// NewSRAGroup creates a group for the given safe prime modulus
+func NewSRAGroup(p *big.Int) (*SRAGroup, error) {
+	if p == nil || p.BitLen() < 64 || !p.ProbablyPrime(20) {
+		return nil, ErrInvalidGroup
+	}
+	q := new(big.Int).Rsh(p, 1)
+	if !q.ProbablyPrime(20) {
+		return nil, ErrInvalidGroup
+	}
+	return &SRAGroup{p: new(big.Int).Set(p), q: q}, nil
+}
+
+// DefaultSRAGroup returns the group over the RFC 3526 2048-bit safe prime
+func DefaultSRAGroup() *SRAGroup {
+	p, _ := new(big.Int).SetString(rfc3526Group14, 16)
+	return &SRAGroup{p: p, q: new(big.Int).Rsh(p, 1)}
+}
+
+// GenerateKey creates a random key pair using the given source of randomness
+func (g *SRAGroup) GenerateKey(random io.Reader) (*SRAKey, error) {
+	// Any exponent in [2, q-1] is invertible modulo the prime q
+	limit := new(big.Int).Sub(g.q, big.NewInt(2))
+	e, err := rand.Int(random, limit)
+	if err != nil {
+		return nil, err
+	}
+	e.Add(e, big.NewInt(2))
+
+	return &SRAKey{
+		E: e,
+		D: new(big.Int).ModInverse(e, g.q),
+	}, nil
+}
+
+// validKey reports whether the exponents of a key invert each other
+func (g *SRAGroup) validKey(key *SRAKey) bool {
+	if key == nil || key.E == nil || key.D == nil {
+		return false
+	}
+	product := new(big.Int).Mul(key.E, key.D)
+	return product.Mod(product, g.q).Cmp(big.NewInt(1)) == 0
+}
+
+// encodeCard maps a card value to its plaintext group element (value+2)^2 mod p
+func (g *SRAGroup) encodeCard(value int) *big.Int {
+	m := big.NewInt(int64(value + 2))
+	return m.Exp(m, big.NewInt(2), g.p)
+}
+
+// decodeCard maps a plaintext group element back to a card
+func (g *SRAGroup) decodeCard(m *big.Int) (types.Card, error) {
+	for value := 0; value < 52; value++ {
+		if g.encodeCard(value).Cmp(m) == 0 {
+			return cardFromValue(value), nil
+		}
+	}
+	return types.Card{}, ErrInvalidCiphertext
+}
+
+// exp raises x to the product of the exponents modulo p
+func (g *SRAGroup) exp(x *big.Int, exponents ...*big.Int) *big.Int {
+	k := big.NewInt(1)
+	for _, e := range exponents {
+		k.Mul(k, e)
+		k.Mod(k, g.q)
+	}
+	return new(big.Int).Exp(x, k, g.p)
+}
+
+// inSubgroup reports whether x is an element of the subgroup of order q
+func (g *SRAGroup) inSubgroup(x *big.Int) bool {
+	if x.Sign() <= 0 || x.Cmp(g.p) >= 0 {
+		return false
+	}
+	return new(big.Int).Exp(x, g.q, g.p).Cmp(big.NewInt(1)) == 0
+}
+
+// shuffleStep encrypts every card with the shuffle key and reorders the deck
+// so that position i receives the card previously at permutation[i]
+func (g *SRAGroup) shuffleStep(cards []*big.Int, key *SRAKey, permutation []int) []*big.Int {
+	next := make([]*big.Int, len(cards))
+	for i, from := range permutation {
+		next[i] = g.exp(cards[from], key.E)
+	}
+	return next
+}
+
+// lockStep removes the shuffle key and encrypts each position with its card key
+func (g *SRAGroup) lockStep(cards []*big.Int, shuffleKey *SRAKey, cardKeys []*SRAKey) []*big.Int {
+	next := make([]*big.Int, len(cards))
+	for i, card := range cards {
+		next[i] = g.exp(card, shuffleKey.D, cardKeys[i].E)
+	}
+	return next
+}
+
+// encryptedDeckPhase is the stage of the mental poker protocol
+type encryptedDeckPhase int
+
+const (
+	phaseShuffle encryptedDeckPhase = iota
+	phaseLock
+	phasePlay
+)
+
+// SRAReveal is what a player discloses after the hand so the whole
+// encrypt/shuffle round can be replayed and checked
+type SRAReveal struct {
+	ShuffleKey  *SRAKey
+	Permutation []int
+	CardKeys    []*SRAKey
+}
+
+// SRAPlayer holds the secret material one player uses in the protocol.
+// It never leaves the player; only ciphertexts, key checks and individual
+// released keys are sent to the shared EncryptedDeck.
+type SRAPlayer struct {
+	address     string
+	group       *SRAGroup
+	shuffleKey  *SRAKey
+	permutation []int
+	cardKeys    []*SRAKey
+}
+
+// NewSRAPlayer generates a shuffle key, permutation and per-card keys
+func NewSRAPlayer(address string, group *SRAGroup, random io.Reader) (*SRAPlayer, error) {
+	shuffleKey, err := group.GenerateKey(random)
+	if err != nil {
+		return nil, err
+	}
+	permutation, err := RandomPermutation(52, random)
+	if err != nil {
+		return nil, err
+	}
+	cardKeys := make([]*SRAKey, 52)
+	for i := range cardKeys {
+		if cardKeys[i], err = group.GenerateKey(random); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SRAPlayer{
+		address:     address,
+		group:       group,
+		shuffleKey:  shuffleKey,
+		permutation: permutation,
+		cardKeys:    cardKeys,
+	}, nil
+}
+
+// GetAddress returns the player's address
+func (p *SRAPlayer) GetAddress() string {
+	return p.address
+}
+
+// Shuffle encrypts and permutes the shared deck and submits the result
+func (p *SRAPlayer) Shuffle(deck *EncryptedDeck) error {
+	cards := p.group.shuffleStep(deck.Ciphertexts(), p.shuffleKey, p.permutation)
+	return deck.SubmitShuffle(p.address, cards)
+}
+
+// Lock swaps the shuffle key for per-card keys and submits the result
+func (p *SRAPlayer) Lock(deck *EncryptedDeck) error {
+	cards := p.group.lockStep(deck.Ciphertexts(), p.shuffleKey, p.cardKeys)
+	checks := make([]*big.Int, len(p.cardKeys))
+	for i, key := range p.cardKeys {
+		checks[i] = p.group.exp(keyCheckBase, key.E)
+	}
+	return deck.SubmitLock(p.address, cards, checks)
+}
+
+// CardKey returns the decryption key for a single position
+func (p *SRAPlayer) CardKey(position int) *big.Int {
+	return new(big.Int).Set(p.cardKeys[position].D)
+}
+
+// ReleaseKey publishes the player's key for a position to the shared deck
+func (p *SRAPlayer) ReleaseKey(deck *EncryptedDeck, position int) error {
+	return deck.ReleaseKey(p.address, position, p.CardKey(position))
+}
+
+// PeekCard decrypts a card dealt to this player without releasing its key
+func (p *SRAPlayer) PeekCard(deck *EncryptedDeck, position int) (types.Card, error) {
+	return deck.PeekCard(position, p.address, p.CardKey(position))
+}
+
+// Reveal discloses all secret material for post-hand verification
+func (p *SRAPlayer) Reveal() SRAReveal {
+	return SRAReveal{
+		ShuffleKey:  p.shuffleKey,
+		Permutation: append([]int(nil), p.permutation...),
+		CardKeys:    append([]*SRAKey(nil), p.cardKeys...),
+	}
+}
+
+// EncryptedDeck is an SRA-style mental poker deck.
+//
+// It is the shared, public state; each player's keys stay in their
+// own SRAPlayer. The protocol runs in three phases:
+//  1. Shuffle: each player in turn encrypts every card with one key and
+//     permutes the deck (SubmitShuffle).
+//  2. Lock: each player in turn removes their shuffle key and re-encrypts each
+//     position with its own card key, publishing g^e for every card key
+//     (SubmitLock).
+//  3. Play: cards are dealt by position. A card is read once every other
+//     player has released their decryption key for that position; released
+//     keys are checked against the published g^e.
+//
+// No single player ever learns the order of the deck, and every step is kept
+// so the hand can be verified once players reveal their keys.
+type EncryptedDeck struct {
+	group     *SRAGroup
+	players   []string
+	phase     encryptedDeckPhase
+	turn      int
+	cards     []*big.Int
+	steps     [][]*big.Int
+	keyChecks map[string][]*big.Int
+	released  []map[string]*big.Int
+	top       int
+}
+
+// NewEncryptedDeck creates an unencrypted standard deck for the given players.
+// Players encrypt, shuffle and lock in the order given.
+func NewEncryptedDeck(group *SRAGroup, players []string) (*EncryptedDeck, error) {
+	if len(players) == 0 {
+		return nil, ErrNoParticipants
+	}
+	seen := make(map[string]bool, len(players))
+	for _, player := range players {
+		if seen[player] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateParticipant, player)
+		}
+		seen[player] = true
+	}
+
+	d := &EncryptedDeck{
+		group:     group,
+		players:   append([]string(nil), players...),
+		phase:     phaseShuffle,
+		cards:     initialCiphertexts(group),
+		keyChecks: make(map[string][]*big.Int, len(players)),
+		released:  make([]map[string]*big.Int, 52),
+	}
+	for i := range d.released {
+		d.released[i] = make(map[string]*big.Int)
+	}
+	return d, nil
+}
+
+// initialCiphertexts returns the encoded standard deck in value order
+func initialCiphertexts(group *SRAGroup) []*big.Int {
+	cards := make([]*big.Int, 52)
+	for value := range cards {
+		cards[value] = group.encodeCard(value)
+	}
+	return cards
+}
+
+// Ciphertexts returns a copy of the current encrypted deck
+func (d *EncryptedDeck) Ciphertexts() []*big.Int {
+	cards := make([]*big.Int, len(d.cards))
+	for i, card := range d.cards {
+		cards[i] = new(big.Int).Set(card)
+	}
+	return cards
+}
+
+// SubmitShuffle records the deck a player produced in the shuffle phase
+func (d *EncryptedDeck) SubmitShuffle(player string, cards []*big.Int) error {
+	if err := d.checkTurn(player, phaseShuffle); err != nil {
+		return err
+	}
+	if err := d.checkCiphertexts(cards); err != nil {
+		return err
+	}
+
+	d.cards = cards
+	d.advance(phaseLock)
+	return nil
+}
+
+// SubmitLock records the deck a player produced in the lock phase together
+// with the public g^e commitment for each of their card keys
+func (d *EncryptedDeck) SubmitLock(player string, cards []*big.Int, keyChecks []*big.Int) error {
+	if err := d.checkTurn(player, phaseLock); err != nil {
+		return err
+	}
+	if err := d.checkCiphertexts(cards); err != nil {
+		return err
+	}
+	if len(keyChecks) != len(cards) {
+		return fmt.Errorf("%w: expected %d key checks, got %d", ErrInvalidKey, len(cards), len(keyChecks))
+	}
+	for i, check := range keyChecks {
+		if check == nil || !d.group.inSubgroup(check) {
+			return fmt.Errorf("%w: key check %d for %s", ErrInvalidKey, i, player)
+		}
+	}
+
+	d.cards = cards
+	d.keyChecks[player] = keyChecks
+	d.advance(phasePlay)
+	return nil
+}
+
+// checkCiphertexts validates a submitted deck: the right number of distinct
+// elements of the prime order subgroup
+func (d *EncryptedDeck) checkCiphertexts(cards []*big.Int) error {
+	if len(cards) != len(d.cards) {
+		return fmt.Errorf("%w: expected %d cards, got %d", ErrInvalidCiphertext, len(d.cards), len(cards))
+	}
+	seen := make(map[string]bool, len(cards))
+	for i, card := range cards {
+		if card == nil || !d.group.inSubgroup(card) {
+			return fmt.Errorf("%w: position %d", ErrInvalidCiphertext, i)
+		}
+		key := card.Text(16)
+		if seen[key] {
+			return fmt.Errorf("%w: duplicate at position %d", ErrInvalidCiphertext, i)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// checkTurn verifies the phase and that the player is next in order
+func (d *EncryptedDeck) checkTurn(player string, phase encryptedDeckPhase) error {
+	if d.phase != phase {
+		return ErrWrongPhase
+	}
+	if d.players[d.turn] != player {
+		return fmt.Errorf("%w: expected %s, got %s", ErrOutOfTurn, d.players[d.turn], player)
+	}
+	return nil
+}
+
+// advance records the current deck state and moves to the next player,
+// entering the given phase once every player has taken their turn
+func (d *EncryptedDeck) advance(nextPhase encryptedDeckPhase) {
+	d.steps = append(d.steps, d.cards)
+	d.turn++
+	if d.turn == len(d.players) {
+		d.turn = 0
+		d.phase = nextPhase
+	}
+}
+
+// Deal reserves the next amount positions of the deck
+func (d *EncryptedDeck) Deal(amount int) ([]int, error) {
+	if d.phase != phasePlay {
+		return nil, ErrWrongPhase
+	}
+	if d.top+amount > len(d.cards) {
+		return nil, errors.New("not enough cards in deck")
+	}
+
+	positions := make([]int, amount)
+	for i := range positions {
+		positions[i] = d.top
+		d.top++
+	}
+	return positions, nil
+}
+
+// ReleaseKey publishes a player's decryption key for a single position.
+// The key is checked against the commitment made when the deck was locked.
+func (d *EncryptedDeck) ReleaseKey(player string, position int, key *big.Int) error {
+	if d.phase != phasePlay {
+		return ErrWrongPhase
+	}
+	if position < 0 || position >= len(d.cards) {
+		return fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+	}
+	checks, ok := d.keyChecks[player]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownParticipant, player)
+	}
+	if key == nil || d.group.exp(checks[position], key).Cmp(keyCheckBase) != 0 {
+		return fmt.Errorf("%w: position %d for %s", ErrInvalidKey, position, player)
+	}
+
+	d.released[position][player] = new(big.Int).Set(key)
+	return nil
+}
+
+// PeekCard privately decrypts a card for the player entitled to it.
+// Every other player must already have released their key for the position.
+func (d *EncryptedDeck) PeekCard(position int, player string, key *big.Int) (types.Card, error) {
+	if d.phase != phasePlay {
+		return types.Card{}, ErrWrongPhase
+	}
+	if position < 0 || position >= len(d.cards) {
+		return types.Card{}, fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+	}
+	checks, ok := d.keyChecks[player]
+	if !ok {
+		return types.Card{}, fmt.Errorf("%w: %s", ErrUnknownParticipant, player)
+	}
+	if key == nil || d.group.exp(checks[position], key).Cmp(keyCheckBase) != 0 {
+		return types.Card{}, fmt.Errorf("%w: position %d for %s", ErrInvalidKey, position, player)
+	}
+
+	keys := []*big.Int{key}
+	for _, other := range d.players {
+		if other == player {
+			continue
+		}
+		released, ok := d.released[position][other]
+		if !ok {
+			return types.Card{}, fmt.Errorf("%w: position %d by %s", ErrKeyNotReleased, position, other)
+		}
+		keys = append(keys, released)
+	}
+	return d.group.decodeCard(d.group.exp(d.cards[position], keys...))
+}
+
+// RevealCard publicly decrypts a card once every player has released their key
+func (d *EncryptedDeck) RevealCard(position int) (types.Card, error) {
+	if d.phase != phasePlay {
+		return types.Card{}, ErrWrongPhase
+	}
+	if position < 0 || position >= len(d.cards) {
+		return types.Card{}, fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+	}
+
+	keys := make([]*big.Int, 0, len(d.players))
+	for _, player := range d.players {
+		released, ok := d.released[position][player]
+		if !ok {
+			return types.Card{}, fmt.Errorf("%w: position %d by %s", ErrKeyNotReleased, position, player)
+		}
+		keys = append(keys, released)
+	}
+	return d.group.decodeCard(d.group.exp(d.cards[position], keys...))
+}
+
+// GetTop returns the next position to be dealt
+func (d *EncryptedDeck) GetTop() int {
+	return d.top
+}
+
+// GetHash returns the SHA256 hash of the current ciphertexts
+func (d *EncryptedDeck) GetHash() string {
+	return hashCiphertexts(d.cards)
+}
+
+// hashCiphertexts hashes hex encoded ciphertexts joined with "-"
+func hashCiphertexts(cards []*big.Int) string {
+	encoded := make([]string, len(cards))
+	for i, card := range cards {
+		encoded[i] = card.Text(16)
+	}
+	hash := sha256.Sum256([]byte(strings.Join(encoded, "-")))
+	return hex.EncodeToString(hash[:])
+}
+
+// Verify replays the whole encrypt/shuffle round from the keys and
+// permutations every player reveals after the hand. It checks each recorded
+// step, then decrypts the final ciphertexts and returns the plaintext deck in
+// dealt order.
+func (d *EncryptedDeck) Verify(reveals map[string]SRAReveal) (*Deck, error) {
+	if d.phase != phasePlay {
+		return nil, ErrWrongPhase
+	}
+
+	for _, player := range d.players {
+		reveal, ok := reveals[player]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingReveal, player)
+		}
+		if !d.group.validKey(reveal.ShuffleKey) || len(reveal.CardKeys) != len(d.cards) {
+			return nil, fmt.Errorf("%w: keys for %s", ErrInvalidKey, player)
+		}
+		for _, key := range reveal.CardKeys {
+			if !d.group.validKey(key) {
+				return nil, fmt.Errorf("%w: keys for %s", ErrInvalidKey, player)
+			}
+		}
+		if err := validatePermutation(reveal.Permutation, len(d.cards)); err != nil {
+			return nil, fmt.Errorf("%s: %w", player, err)
+		}
+	}
+
+	cards := initialCiphertexts(d.group)
+	step := 0
+	for _, player := range d.players {
+		reveal := reveals[player]
+		cards = d.group.shuffleStep(cards, reveal.ShuffleKey, reveal.Permutation)
+		if hashCiphertexts(cards) != hashCiphertexts(d.steps[step]) {
+			return nil, fmt.Errorf("%w: shuffle by %s", ErrTranscriptMismatch, player)
+		}
+		step++
+	}
+	for _, player := range d.players {
+		reveal := reveals[player]
+		cards = d.group.lockStep(cards, reveal.ShuffleKey, reveal.CardKeys)
+		if hashCiphertexts(cards) != hashCiphertexts(d.steps[step]) {
+			return nil, fmt.Errorf("%w: lock by %s", ErrTranscriptMismatch, player)
+		}
+		step++
+	}
+
+	mnemonics := make([]string, len(cards))
+	for i, card := range cards {
+		keys := make([]*big.Int, 0, len(d.players))
+		for _, player := range d.players {
+			keys = append(keys, reveals[player].CardKeys[i].D)
+		}
+		plain, err := d.group.decodeCard(d.group.exp(card, keys...))
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %w", i, err)
+		}
+		mnemonics[i] = plain.Mnemonic
+	}
+
+	deck, err := NewDeck(strings.Join(mnemonics, "-"))
+	if err != nil {
+		return nil, err
+	}
+	deck.top = d.top
+	return deck, nil
+}
+
+// validatePermutation checks that permutation is a permutation of [0, n)
+func validatePermutation(permutation []int, n int) error {
+	if len(permutation) != n {
+		return fmt.Errorf("%w: expected %d entries, got %d", ErrInvalidPermutation, n, len(permutation))
+	}
+	seen := make([]bool, n)
+	for _, index := range permutation {
+		if index < 0 || index >= n || seen[index] {
+			return fmt.Errorf("%w: bad index %d", ErrInvalidPermutation, index)
+		}
+		seen[index] = true
+	}
+	return nil
+}
+
+// RandomPermutation returns a uniformly random permutation of [0, n)
+func RandomPermutation(n int, random io.Reader) ([]int, error) {
+	permutation := make([]int, n)
+	for i := range permutation {
+		permutation[i] = i
+	}
+
+	var err error
+	fisherYates(n, func(bound int) int {
+		if err != nil {
+			return 0
+		}
+		var r *big.Int
+		r, err = rand.Int(random, big.NewInt(int64(bound)))
+		if err != nil {
+			return 0
+		}
+		return int(r.Int64())
+	}, func(i, j int) {
+		permutation[i], permutation[j] = permutation[j], permutation[i]
+	})
+	if err != nil {
+		return nil, err
+	}
+	return permutation, nil
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// testSafePrime is a 512-bit safe prime so tests stay fast
+const testSafePrime = "D5A6A082C03F3D98236CA4469FC22F3DF58C9909F604CF74D40FED0073BAC945" +
+	"F478BFE902257544261114000CCD35B1C189D49AFCEA990609FDF8677A3F3DA3"
+
+// newTestTable creates an encrypted deck with simulated players that have
+// completed the shuffle and lock phases
+func newTestTable(t *testing.T, addresses []string) (*EncryptedDeck, []*SRAPlayer) {
+	t.Helper()
+
+	p, _ := new(big.Int).SetString(testSafePrime, 16)
+	group, err := NewSRAGroup(p)
+	if err != nil {
+		t.Fatalf("NewSRAGroup failed: %v", err)
+	}
+
+	deck, err := NewEncryptedDeck(group, addresses)
+	if err != nil {
+		t.Fatalf("NewEncryptedDeck failed: %v", err)
+	}
+
+	random := rand.NewChaCha8([32]byte{52})
+	players := make([]*SRAPlayer, len(addresses))
+	for i, address := range addresses {
+		if players[i], err = NewSRAPlayer(address, group, random); err != nil {
+			t.Fatalf("NewSRAPlayer failed: %v", err)
+		}
+	}
+	for _, player := range players {
+		if err := player.Shuffle(deck); err != nil {
+			t.Fatalf("Shuffle failed: %v", err)
+		}
+	}
+	for _, player := range players {
+		if err := player.Lock(deck); err != nil {
+			t.Fatalf("Lock failed: %v", err)
+		}
+	}
+	return deck, players
+}
+
+// TestEncryptedDeck tests the mental poker protocol with simulated players
+func TestEncryptedDeck(t *testing.T) {
+	addresses := []string{"0x1", "0x2", "0x3"}
+
+	t.Run("should let only the owner read a hole card", func(t *testing.T) {
+		deck, players := newTestTable(t, addresses)
+		positions, err := deck.Deal(1)
+		if err != nil {
+			t.Fatalf("Deal failed: %v", err)
+		}
+		position := positions[0]
+
+		if _, err := players[0].PeekCard(deck, position); !errors.Is(err, ErrKeyNotReleased) {
+			t.Errorf("Expected ErrKeyNotReleased before releases, got %v", err)
+		}
+
+		for _, other := range players[1:] {
+			if err := other.ReleaseKey(deck, position); err != nil {
+				t.Fatalf("ReleaseKey failed: %v", err)
+			}
+		}
+
+		card, err := players[0].PeekCard(deck, position)
+		if err != nil {
+			t.Fatalf("PeekCard failed: %v", err)
+		}
+		if card.Mnemonic == "" {
+			t.Error("Expected a decrypted card")
+		}
+
+		if _, err := deck.RevealCard(position); !errors.Is(err, ErrKeyNotReleased) {
+			t.Errorf("Expected card to stay private, got %v", err)
+		}
+	})
+
+	t.Run("should reveal board cards once every key is released", func(t *testing.T) {
+		deck, players := newTestTable(t, addresses)
+		positions, _ := deck.Deal(3)
+
+		seen := make(map[string]bool)
+		for _, position := range positions {
+			for _, player := range players {
+				if err := player.ReleaseKey(deck, position); err != nil {
+					t.Fatalf("ReleaseKey failed: %v", err)
+				}
+			}
+			card, err := deck.RevealCard(position)
+			if err != nil {
+				t.Fatalf("RevealCard failed: %v", err)
+			}
+			if seen[card.Mnemonic] {
+				t.Errorf("Duplicate card %s", card.Mnemonic)
+			}
+			seen[card.Mnemonic] = true
+		}
+	})
+
+	t.Run("should reject a bogus key release", func(t *testing.T) {
+		deck, players := newTestTable(t, addresses)
+		positions, _ := deck.Deal(1)
+
+		err := deck.ReleaseKey("0x2", positions[0], players[1].CardKey(positions[0]+1))
+		if !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expected ErrInvalidKey, got %v", err)
+		}
+	})
+
+	t.Run("should enforce turn order and phases", func(t *testing.T) {
+		p, _ := new(big.Int).SetString(testSafePrime, 16)
+		group, _ := NewSRAGroup(p)
+		deck, _ := NewEncryptedDeck(group, addresses)
+		player, _ := NewSRAPlayer("0x2", group, rand.NewChaCha8([32]byte{1}))
+
+		if err := player.Shuffle(deck); !errors.Is(err, ErrOutOfTurn) {
+			t.Errorf("Expected ErrOutOfTurn, got %v", err)
+		}
+		if err := player.Lock(deck); !errors.Is(err, ErrWrongPhase) {
+			t.Errorf("Expected ErrWrongPhase, got %v", err)
+		}
+		if _, err := deck.Deal(1); !errors.Is(err, ErrWrongPhase) {
+			t.Errorf("Expected ErrWrongPhase, got %v", err)
+		}
+	})
+
+	t.Run("should verify the hand from revealed keys", func(t *testing.T) {
+		deck, players := newTestTable(t, addresses)
+		positions, _ := deck.Deal(2)
+		for _, other := range players[1:] {
+			_ = other.ReleaseKey(deck, positions[0])
+		}
+		peeked, _ := players[0].PeekCard(deck, positions[0])
+
+		reveals := make(map[string]SRAReveal)
+		for _, player := range players {
+			reveals[player.GetAddress()] = player.Reveal()
+		}
+
+		plain, err := deck.Verify(reveals)
+		if err != nil {
+			t.Fatalf("Verify failed: %v", err)
+		}
+		if plain.cards[positions[0]].Mnemonic != peeked.Mnemonic {
+			t.Errorf("Expected position %d to be %s, got %s", positions[0], peeked.Mnemonic, plain.cards[positions[0]].Mnemonic)
+		}
+		if plain.GetTop() != 2 {
+			t.Errorf("Expected top to be 2, got %d", plain.GetTop())
+		}
+	})
+
+	t.Run("should detect keys that do not reproduce the transcript", func(t *testing.T) {
+		deck, players := newTestTable(t, addresses)
+
+		reveals := make(map[string]SRAReveal)
+		for _, player := range players {
+			reveals[player.GetAddress()] = player.Reveal()
+		}
+		tampered := reveals["0x2"]
+		tampered.Permutation[0], tampered.Permutation[1] = tampered.Permutation[1], tampered.Permutation[0]
+		reveals["0x2"] = tampered
+
+		if _, err := deck.Verify(reveals); !errors.Is(err, ErrTranscriptMismatch) {
+			t.Errorf("Expected ErrTranscriptMismatch, got %v", err)
+		}
+	})
+}
+
+// TestSRAGroup tests commutativity of the cipher
+func TestSRAGroup(t *testing.T) {
+	t.Run("should decrypt layers in any order", func(t *testing.T) {
+		p, _ := new(big.Int).SetString(testSafePrime, 16)
+		group, _ := NewSRAGroup(p)
+		random := rand.NewChaCha8([32]byte{7})
+		a, _ := group.GenerateKey(random)
+		b, _ := group.GenerateKey(random)
+
+		m := group.encodeCard(39)
+		c := group.exp(group.exp(m, a.E), b.E)
+		plain := group.exp(group.exp(c, a.D), b.D)
+
+		card, err := group.decodeCard(plain)
+		if err != nil {
+			t.Fatalf("decodeCard failed: %v", err)
+		}
+		if card.Mnemonic != "AS" {
+			t.Errorf("Expected AS, got %s", card.Mnemonic)
+		}
+	})
+
+	t.Run("should reject a modulus that is not a safe prime", func(t *testing.T) {
+		if _, err := NewSRAGroup(big.NewInt(1000003)); !errors.Is(err, ErrInvalidGroup) {
+			t.Errorf("Expected ErrInvalidGroup, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// ErrNotDealt is returned when reshuffling a card that is still in the deck
+var ErrNotDealt = errors.New("card has not been dealt")
+
+// seedStream is a deterministic byte stream derived from a seed.
+//
+// Block i of the stream is SHA256(seed || uint32be(i)), starting at i = 0.
+// Bytes are consumed in order and a new block is generated whenever the
+// current one is exhausted. The construction only relies on SHA-256 and
+// big-endian integers so it can be reproduced byte-for-byte in TypeScript.
+type seedStream struct {
+	seed    []byte
+	counter uint32
+	block   [sha256.Size]byte
+	offset  int
+}
+
+// newSeedStream creates a stream for the given seed
+func newSeedStream(seed []byte) *seedStream {
+	return &seedStream{
+		seed:   append([]byte(nil), seed...),
+		offset: sha256.Size,
+	}
+}
+
+// nextUint32 returns the next 4 bytes of the stream as a big-endian uint32
+func (s *seedStream) nextUint32() uint32 {
+	var buf [4]byte
+	for i := range buf {
+		if s.offset == sha256.Size {
+			s.refill()
+		}
+		buf[i] = s.block[s.offset]
+		s.offset++
+	}
+	return binary.BigEndian.Uint32(buf[:])
+}
+
+// refill generates the next SHA-256 block of the stream
+func (s *seedStream) refill() {
+	var counter [4]byte
+	binary.BigEndian.PutUint32(counter[:], s.counter)
+
+	h := sha256.New()
+	h.Write(s.seed)
+	h.Write(counter[:])
+	copy(s.block[:], h.Sum(nil))
+
+	s.counter++
+	s.offset = 0
+}
+
+// intn returns a uniformly distributed integer in [0, n) using rejection
+// sampling, so the result carries no modulo bias
+func (s *seedStream) intn(n int) int {
+	bound := uint64(n)
+	limit := (uint64(1) << 32) - ((uint64(1) << 32) % bound)
+	for {
+		r := uint64(s.nextUint32())
+		if r < limit {
+			return int(r % bound)
+		}
+	}
+}
+
+// fisherYates shuffles n elements in place using the given random source.
+// Iterates i from n-1 down to 1 and swaps element i with element j in [0, i].
+func fisherYates(n int, intn func(int) int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		j := intn(i + 1)
+		swap(i, j)
+	}
+}
+
+// NewShuffledDeck creates a standard 52-card deck shuffled with the given seed
+func NewShuffledDeck(seed []byte) (*Deck, error) {
+	d, err := NewDeck("")
+	if err != nil {
+		return nil, err
+	}
+	d.Shuffle(seed)
+	return d, nil
+}
+
+// Shuffle deterministically reorders the deck from a seed.
+//
+// The algorithm is a Fisher–Yates shuffle over the current card order, where
+// each index j in [0, i] is drawn from the SHA-256 seed stream: take the next
+// big-endian uint32 r, reject it while r >= 2^32 - (2^32 mod (i+1)), then
+// j = r mod (i+1). The same seed always yields the same order.
+//
+// Shuffling resets the top of the deck and the audit log and recomputes the hash.
+func (d *Deck) Shuffle(seed []byte) {
+	stream := newSeedStream(seed)
+	fisherYates(len(d.cards), stream.intn, func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+	d.top = 0
+	d.audit = nil
+	d.start = nil
+	d.createHash()
+}
+
+// Reshuffle returns dealt cards to the bottom of the deck in an order shuffled
+// from the seed, as when a draw game runs out of cards and the discards are
+// shuffled to form a new stub. Cards still to be dealt stay ahead of them.
+//
+// Every card must already have been dealt. The other dealt cards keep their
+// order at the front of the deck and the hash is recomputed. The audit log
+// keeps its entries and records each returned card at its new position with
+// a commitment to the seed, hex(SHA256(seed)), and the deck remembers its
+// order from before the first reshuffle so VerifyAudit can replay the log.
+func (d *Deck) Reshuffle(cards []types.Card, seed []byte) error {
+	dealt := make(map[int]bool, d.top)
+	for _, card := range d.cards[:d.top] {
+		dealt[card.Value] = true
+	}
+	returned := make(map[int]bool, len(cards))
+	for _, card := range cards {
+		if !dealt[card.Value] {
+			return fmt.Errorf("%w: %s", ErrNotDealt, card.Mnemonic)
+		}
+		if returned[card.Value] {
+			return fmt.Errorf("%w: %s", ErrDuplicateCard, card.Mnemonic)
+		}
+		returned[card.Value] = true
+	}
+
+	kept := make([]types.Card, 0, len(d.cards))
+	var back []types.Card
+	for _, card := range d.cards[:d.top] {
+		if returned[card.Value] {
+			back = append(back, card)
+		} else {
+			kept = append(kept, card)
+		}
+	}
+
+	stream := newSeedStream(seed)
+	fisherYates(len(back), stream.intn, func(i, j int) {
+		back[i], back[j] = back[j], back[i]
+	})
+
+	if d.start == nil {
+		d.start = append([]types.Card(nil), d.cards...)
+		d.startTop = d.top
+	}
+	top := len(kept)
+	d.cards = append(append(kept, d.cards[d.top:]...), back...)
+	d.top = top
+	commitment := CommitSecret(seed)
+	for i, card := range back {
+		d.audit = append(d.audit, AuditEntry{
+			Position:    len(d.cards) - len(back) + i,
+			Card:        card.Mnemonic,
+			Destination: DestinationReshuffle,
+			Commitment:  commitment,
+		})
+	}
+	d.createHash()
+	return nil
+}
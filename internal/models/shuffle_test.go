@@ -0,0 +1,184 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// TestDeck_Shuffle tests the deterministic seeded shuffle
+func TestDeck_Shuffle(t *testing.T) {
+	t.Run("should produce the reference order for a known seed", func(t *testing.T) {
+		// Order recorded from this implementation; a change means decks shuffled
+		// from the same seed no longer match
+		expected := "[TH]-5C-QD-7D-TD-AC-8S-6C-8H-QC-5H-KD-9C-JH-5S-TC-JC-2S-4C-JS-4D-KH-2H-QH-6D-" +
+			"7C-6H-KS-3C-QS-7H-KC-JD-AD-6S-4H-7S-3H-AS-AH-9S-2C-3S-4S-2D-TS-3D-9D-8D-5D-8C-9H"
+
+		deck, err := NewShuffledDeck([]byte("block52"))
+		if err != nil {
+			t.Fatalf("NewShuffledDeck failed: %v", err)
+		}
+
+		if deck.ToString() != expected {
+			t.Errorf("Unexpected shuffle order.\nGot:      %s\nExpected: %s", deck.ToString(), expected)
+		}
+		if deck.GetHash() != "3f1cd6c25938df3390f9d30c0134d995bb34ea8394d63fd250fce964c3277db4" {
+			t.Errorf("Unexpected hash: %s", deck.GetHash())
+		}
+	})
+
+	t.Run("should produce the same order for the same seed", func(t *testing.T) {
+		deck1, _ := NewShuffledDeck([]byte("seed"))
+		deck2, _ := NewShuffledDeck([]byte("seed"))
+
+		if deck1.ToString() != deck2.ToString() {
+			t.Error("Expected identical decks for identical seeds")
+		}
+		if deck1.GetHash() != deck2.GetHash() {
+			t.Error("Expected identical hashes for identical seeds")
+		}
+	})
+
+	t.Run("should produce different orders for different seeds", func(t *testing.T) {
+		deck1, _ := NewShuffledDeck([]byte("seed-1"))
+		deck2, _ := NewShuffledDeck([]byte("seed-2"))
+
+		if deck1.GetHash() == deck2.GetHash() {
+			t.Error("Expected different hashes for different seeds")
+		}
+	})
+
+	t.Run("should keep every card exactly once", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("permutation"))
+
+		seen := make(map[int]bool)
+		for _, card := range deck.cards {
+			if seen[card.Value] {
+				t.Fatalf("Duplicate card %s after shuffle", card.Mnemonic)
+			}
+			seen[card.Value] = true
+		}
+		if len(seen) != 52 {
+			t.Errorf("Expected 52 distinct cards, got %d", len(seen))
+		}
+	})
+
+	t.Run("should reset top and update hash", func(t *testing.T) {
+		deck, _ := NewDeck("")
+		standardHash := deck.GetHash()
+		if _, err := deck.Deal(5); err != nil {
+			t.Fatalf("Deal failed: %v", err)
+		}
+
+		deck.Shuffle([]byte("reset"))
+
+		if deck.GetTop() != 0 {
+			t.Errorf("Expected top to be 0, got %d", deck.GetTop())
+		}
+		if deck.GetHash() == standardHash {
+			t.Error("Expected hash to change after shuffle")
+		}
+
+		roundTrip, err := NewDeck(deck.ToString())
+		if err != nil {
+			t.Fatalf("NewDeck failed: %v", err)
+		}
+		if roundTrip.GetHash() != deck.GetHash() {
+			t.Error("Expected hash to match after string round trip")
+		}
+	})
+}
+
+// TestDeck_Reshuffle tests returning dealt cards to the bottom of the deck
+func TestDeck_Reshuffle(t *testing.T) {
+	t.Run("should deal the stub before the reshuffled cards", func(t *testing.T) {
+		deck, _ := NewDeck("")
+		dealt, err := deck.Deal(50)
+		if err != nil {
+			t.Fatalf("Deal failed: %v", err)
+		}
+
+		if err := deck.Reshuffle(dealt[:10], []byte("discards")); err != nil {
+			t.Fatalf("Reshuffle failed: %v", err)
+		}
+		if deck.Remaining() != 12 {
+			t.Fatalf("Expected 12 cards remaining, got %d", deck.Remaining())
+		}
+
+		next, _ := deck.Deal(2)
+		if next[0].Mnemonic != "QS" || next[1].Mnemonic != "KS" {
+			t.Errorf("Expected the stub QS KS first, got %s %s", next[0].Mnemonic, next[1].Mnemonic)
+		}
+		returned := make(map[string]bool)
+		for _, card := range dealt[:10] {
+			returned[card.Mnemonic] = true
+		}
+		rest, _ := deck.Deal(10)
+		for _, card := range rest {
+			if !returned[card.Mnemonic] {
+				t.Errorf("Unexpected card %s after the stub", card.Mnemonic)
+			}
+		}
+	})
+
+	t.Run("should keep a deck that round trips", func(t *testing.T) {
+		deck, _ := NewDeck("")
+		dealt, _ := deck.Deal(52)
+		if err := deck.Reshuffle(dealt[5:20], []byte("round trip")); err != nil {
+			t.Fatalf("Reshuffle failed: %v", err)
+		}
+
+		roundTrip, err := NewDeck(deck.ToString())
+		if err != nil {
+			t.Fatalf("NewDeck failed: %v", err)
+		}
+		if roundTrip.GetHash() != deck.GetHash() || roundTrip.GetTop() != 37 {
+			t.Errorf("Expected the reshuffled deck to round trip with top 37, got %d", roundTrip.GetTop())
+		}
+		if err := VerifyAudit(deck.Audit()); err != nil {
+			t.Errorf("VerifyAudit failed: %v", err)
+		}
+	})
+
+	t.Run("should keep the audit log across a reshuffle", func(t *testing.T) {
+		deck, _ := NewShuffledDeck([]byte("audit"))
+		dealt, _ := deck.DealHole(1, 50)
+		if err := deck.Reshuffle(dealt[:5], []byte("first")); err != nil {
+			t.Fatalf("Reshuffle failed: %v", err)
+		}
+		_, _ = deck.DealHole(1, 4)
+
+		log := deck.GetAuditLog()
+		if len(log) != 59 || log[0].Card != dealt[0].Mnemonic || log[50].Destination != DestinationReshuffle {
+			t.Fatalf("Expected 50 deals, 5 reshuffled cards and 4 deals, got %d entries", len(log))
+		}
+		if log[50].Commitment != CommitSecret([]byte("first")) {
+			t.Errorf("Expected the reshuffle to commit to its seed, got %s", log[50].Commitment)
+		}
+		if err := VerifyAudit(deck.Audit()); err != nil {
+			t.Errorf("VerifyAudit failed: %v", err)
+		}
+
+		audit := deck.Audit()
+		audit.Entries[52].Position++
+		if err := VerifyAudit(audit); !errors.Is(err, ErrAuditMismatch) {
+			t.Errorf("Expected ErrAuditMismatch for a moved reshuffled card, got %v", err)
+		}
+	})
+
+	t.Run("should reject cards not yet dealt", func(t *testing.T) {
+		deck, _ := NewDeck("")
+		dealt, _ := deck.Deal(2)
+		next, _ := deck.GetNext()
+		deck2, _ := NewDeck("")
+		_, _ = deck2.Deal(2)
+
+		if err := deck2.Reshuffle([]types.Card{dealt[0], next}, nil); !errors.Is(err, ErrNotDealt) {
+			t.Errorf("Expected ErrNotDealt, got %v", err)
+		}
+		if err := deck2.Reshuffle([]types.Card{dealt[0], dealt[0]}, nil); !errors.Is(err, ErrDuplicateCard) {
+			t.Errorf("Expected ErrDuplicateCard, got %v", err)
+		}
+	})
+}
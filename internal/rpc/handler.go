@@ -0,0 +1,143 @@
+// Package rpc implements the JSON-RPC interface of the server
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+)
+
+// MethodGetGameState returns the state of a table, params: [tableAddress]
+const MethodGetGameState = "get_game_state"
+
+// Request is a JSON-RPC 2.0 request
+type Request struct {
+	JSONRPC string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+	ID      json.RawMessage   `json:"id"`
+}
+
+// Response is a JSON-RPC 2.0 response carrying either a result or an error
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// Error is a JSON-RPC 2.0 error
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// GameState is the state of a table returned by get_game_state. Pots holds
+// the main pot followed by the side pots in the order they were formed, each
+// with the addresses of the players who can win it.
+type GameState struct {
+	Address     string            `json:"address"`
+	Format      types.GameFormat  `json:"format"`
+	Variant     types.GameVariant `json:"variant"`
+	SmallBlind  *big.Int          `json:"smallBlind"`
+	BigBlind    *big.Int          `json:"bigBlind"`
+	ActionIndex int               `json:"actionIndex"`
+	Pot         *big.Int          `json:"pot"`
+	Pots        []types.Pot       `json:"pots"`
+}
+
+// NewGameState builds the state of the table at address
+func NewGameState(address string, game types.IPoker) GameState {
+	pots := game.GetPots()
+	if pots == nil {
+		pots = []types.Pot{}
+	}
+	return GameState{
+		Address:     address,
+		Format:      game.GetGameFormat(),
+		Variant:     game.GetGameVariant(),
+		SmallBlind:  game.GetSmallBlind(),
+		BigBlind:    game.GetBigBlind(),
+		ActionIndex: game.GetActionIndex(),
+		Pot:         game.GetPot(),
+		Pots:        pots,
+	}
+}
+
+// Handler serves JSON-RPC requests over HTTP POST for the tables it holds.
+// Tables may be added while requests are being served.
+type Handler struct {
+	mu    sync.RWMutex
+	games map[string]types.IPoker
+}
+
+// NewHandler creates a handler with no tables
+func NewHandler() *Handler {
+	return &Handler{games: make(map[string]types.IPoker)}
+}
+
+// AddGame makes a table available at address, replacing any table there
+func (h *Handler) AddGame(address string, game types.IPoker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.games[address] = game
+}
+
+// ServeHTTP decodes a request, dispatches it and writes the response
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req Request
+	resp := Response{JSONRPC: "2.0"}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp.Error = &Error{Code: CodeParseError, Message: err.Error()}
+	} else {
+		resp.ID = req.ID
+		resp.Result, resp.Error = h.dispatch(req)
+	}
+	if resp.ID == nil {
+		resp.ID = json.RawMessage("null")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// dispatch runs a request and returns its result or error
+func (h *Handler) dispatch(req Request) (interface{}, *Error) {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return nil, &Error{Code: CodeInvalidRequest, Message: "expected a JSON-RPC 2.0 request"}
+	}
+
+	switch req.Method {
+	case MethodGetGameState:
+		var address string
+		if len(req.Params) != 1 || json.Unmarshal(req.Params[0], &address) != nil {
+			return nil, &Error{Code: CodeInvalidParams, Message: "expected [tableAddress]"}
+		}
+		h.mu.RLock()
+		game, ok := h.games[address]
+		h.mu.RUnlock()
+		if !ok {
+			return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("no table at %s", address)}
+		}
+		return NewGameState(address, game), nil
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/block52/go-pvm/internal/engine/holdem"
+	"github.com/block52/go-pvm/internal/models"
+	"github.com/block52/go-pvm/internal/types"
+)
+
+// post sends a JSON-RPC request body to the handler and decodes the response
+func post(t *testing.T, handler *Handler, body string) map[string]json.RawMessage {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response %s: %v", rec.Body, err)
+	}
+	return resp
+}
+
+// testOptions returns the options of a no-limit cash table with blinds 1/2
+func testOptions() types.GameOptions {
+	return types.GameOptions{
+		Format:     types.FormatCash,
+		Variant:    types.VariantTexasHoldem,
+		SmallBlind: big.NewInt(1),
+		BigBlind:   big.NewInt(2),
+		MinPlayers: 2,
+		MaxPlayers: 9,
+	}
+}
+
+// sidePotTable returns a three-handed table where alice is all-in for 20 and
+// bob for 50 against carol, leaving a main pot and one side pot
+func sidePotTable(t *testing.T) *holdem.TexasHoldem {
+	t.Helper()
+
+	game, err := holdem.NewTexasHoldem(testOptions(), "")
+	if err != nil {
+		t.Fatalf("NewTexasHoldem failed: %v", err)
+	}
+	for i, p := range []struct {
+		address string
+		stack   int64
+	}{{"alice", 20}, {"bob", 50}, {"carol", 100}} {
+		if err := game.AddPlayer(models.NewPlayer(p.address, big.NewInt(p.stack), i+1)); err != nil {
+			t.Fatalf("AddPlayer failed: %v", err)
+		}
+	}
+
+	act := func(address string, action types.PlayerActionType, amount int64) {
+		if err := game.PerformAction(address, action, game.GetActionIndex(), big.NewInt(amount)); err != nil {
+			t.Fatalf("%s %s %d failed: %v", address, action, amount, err)
+		}
+	}
+	for post := game.GetNextPost(); post != nil && !post.Optional; post = game.GetNextPost() {
+		act(post.Address, post.Action, post.Amount.Int64())
+	}
+	game.Deal()
+	act("alice", types.ActionAllIn, 20)
+	act("bob", types.ActionAllIn, 49)
+	act("carol", types.ActionCall, 48)
+	return game
+}
+
+// TestHandler_GetGameState tests the game state response
+func TestHandler_GetGameState(t *testing.T) {
+	handler := NewHandler()
+	handler.AddGame("0xtable", sidePotTable(t))
+
+	t.Run("should return the main pot and side pots in order", func(t *testing.T) {
+		resp := post(t, handler, `{"jsonrpc":"2.0","method":"get_game_state","params":["0xtable"],"id":7}`)
+		if string(resp["id"]) != "7" || resp["error"] != nil {
+			t.Fatalf("Expected a result for id 7, got %s", resp["error"])
+		}
+
+		var state struct {
+			Pot  int64 `json:"pot"`
+			Pots []struct {
+				Amount   int64    `json:"amount"`
+				Eligible []string `json:"eligible"`
+			} `json:"pots"`
+		}
+		if err := json.Unmarshal(resp["result"], &state); err != nil {
+			t.Fatalf("Invalid game state %s: %v", resp["result"], err)
+		}
+		if state.Pot != 120 || len(state.Pots) != 2 {
+			t.Fatalf("Expected a pot of 120 in two pots, got %s", resp["result"])
+		}
+		if state.Pots[0].Amount != 60 || !reflect.DeepEqual(state.Pots[0].Eligible, []string{"bob", "carol", "alice"}) {
+			t.Errorf("Expected a main pot of 60 for everyone, got %+v", state.Pots[0])
+		}
+		if state.Pots[1].Amount != 60 || !reflect.DeepEqual(state.Pots[1].Eligible, []string{"bob", "carol"}) {
+			t.Errorf("Expected a side pot of 60 for bob and carol, got %+v", state.Pots[1])
+		}
+	})
+
+	t.Run("should return an empty pot list before any bets", func(t *testing.T) {
+		game, err := holdem.NewTexasHoldem(testOptions(), "")
+		if err != nil {
+			t.Fatalf("NewTexasHoldem failed: %v", err)
+		}
+		handler.AddGame("0xempty", game)
+
+		resp := post(t, handler, `{"jsonrpc":"2.0","method":"get_game_state","params":["0xempty"],"id":1}`)
+		if !strings.Contains(string(resp["result"]), `"pots":[]`) {
+			t.Errorf("Expected an empty pots array, got %s", resp["result"])
+		}
+	})
+
+	t.Run("should report unknown tables and methods", func(t *testing.T) {
+		for body, code := range map[string]int{
+			`{"jsonrpc":"2.0","method":"get_game_state","params":["0xnone"],"id":1}`: CodeInvalidParams,
+			`{"jsonrpc":"2.0","method":"get_game_state","params":[],"id":1}`:         CodeInvalidParams,
+			`{"jsonrpc":"2.0","method":"deal","params":[],"id":1}`:                   CodeMethodNotFound,
+			`{"jsonrpc":"2.0"`: CodeParseError,
+		} {
+			var rpcErr Error
+			resp := post(t, handler, body)
+			if err := json.Unmarshal(resp["error"], &rpcErr); err != nil || rpcErr.Code != code {
+				t.Errorf("Expected error %d for %s, got %s", code, body, resp["error"])
+			}
+		}
+	})
+}
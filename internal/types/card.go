@@ -0,0 +1,114 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// rankChars and suitChars map ranks (1-13) and suits (1-4) to mnemonic characters
+const (
+	rankChars = "A23456789TJQK"
+	suitChars = "CDHS"
+)
+
+// CardFromValue returns the card with the given value (0-51)
+// Value is 13 * (suit - 1) + (rank - 1)
+func CardFromValue(value int) (Card, error) {
+	if value < 0 || value > 51 {
+		return Card{}, fmt.Errorf("invalid card value: %d", value)
+	}
+
+	suit := Suit(value/13 + 1)
+	rank := value%13 + 1
+	return Card{
+		Suit:     suit,
+		Rank:     rank,
+		Value:    value,
+		Mnemonic: string([]byte{rankChars[rank-1], suitChars[suit-1]}),
+	}, nil
+}
+
+// MarshalBinary encodes the card as a single byte holding its value
+func (c Card) MarshalBinary() ([]byte, error) {
+	if c.Value < 0 || c.Value > 51 {
+		return nil, fmt.Errorf("invalid card value: %d", c.Value)
+	}
+	return []byte{byte(c.Value)}, nil
+}
+
+// UnmarshalBinary decodes a card encoded by MarshalBinary
+func (c *Card) UnmarshalBinary(data []byte) error {
+	if len(data) != 1 {
+		return fmt.Errorf("card encoding must be 1 byte, got %d", len(data))
+	}
+
+	card, err := CardFromValue(int(data[0]))
+	if err != nil {
+		return err
+	}
+	*c = card
+	return nil
+}
+
+// cardJSON is the card shape used by @block52/poker-vm-sdk
+type cardJSON struct {
+	Suit     *Suit  `json:"suit"`
+	Rank     *int   `json:"rank"`
+	Value    *int   `json:"value"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+// MarshalJSON encodes the card as {"suit", "rank", "value", "mnemonic"}
+func (c Card) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cardJSON{
+		Suit:     &c.Suit,
+		Rank:     &c.Rank,
+		Value:    &c.Value,
+		Mnemonic: c.Mnemonic,
+	})
+}
+
+// UnmarshalJSON decodes a card from the SDK shape.
+// The card is derived from value, or from suit and rank when value is
+// absent; any other fields present must agree with it.
+func (c *Card) UnmarshalJSON(data []byte) error {
+	var raw cardJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var card Card
+	var err error
+	switch {
+	case raw.Value != nil:
+		card, err = CardFromValue(*raw.Value)
+	case raw.Suit != nil && raw.Rank != nil:
+		if *raw.Suit < SuitClubs || *raw.Suit > SuitSpades || *raw.Rank < 1 || *raw.Rank > 13 {
+			return fmt.Errorf("invalid card suit %d or rank %d", *raw.Suit, *raw.Rank)
+		}
+		card, err = CardFromValue(13*(int(*raw.Suit)-1) + (*raw.Rank - 1))
+	default:
+		return fmt.Errorf("card JSON must contain value or suit and rank: %s", data)
+	}
+	if err != nil {
+		return err
+	}
+
+	if raw.Suit != nil && *raw.Suit != card.Suit {
+		return fmt.Errorf("card suit %d does not match value %d", *raw.Suit, card.Value)
+	}
+	if raw.Rank != nil && *raw.Rank != card.Rank {
+		return fmt.Errorf("card rank %d does not match value %d", *raw.Rank, card.Value)
+	}
+	if raw.Mnemonic != "" {
+		mnemonic := strings.ToUpper(raw.Mnemonic)
+		// "10H" is an accepted alias for "TH"
+		if mnemonic != card.Mnemonic && !(card.Rank == 10 && mnemonic == "10"+card.Mnemonic[1:]) {
+			return fmt.Errorf("card mnemonic %s does not match value %d", raw.Mnemonic, card.Value)
+		}
+	}
+
+	*c = card
+	return nil
+}
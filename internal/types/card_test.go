@@ -0,0 +1,108 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestCard_Binary tests the single-byte card encoding
+func TestCard_Binary(t *testing.T) {
+	t.Run("should round trip every card value", func(t *testing.T) {
+		for value := 0; value < 52; value++ {
+			card, err := CardFromValue(value)
+			if err != nil {
+				t.Fatalf("CardFromValue(%d) failed: %v", value, err)
+			}
+
+			data, err := card.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary failed: %v", err)
+			}
+
+			var decoded Card
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary failed: %v", err)
+			}
+			if decoded != card {
+				t.Errorf("Expected %+v, got %+v", card, decoded)
+			}
+		}
+	})
+
+	t.Run("should build mnemonics from values", func(t *testing.T) {
+		tests := map[int]string{0: "AC", 9: "TC", 25: "KD", 39: "AS", 51: "KS"}
+		for value, mnemonic := range tests {
+			card, _ := CardFromValue(value)
+			if card.Mnemonic != mnemonic {
+				t.Errorf("Value %d: expected %s, got %s", value, mnemonic, card.Mnemonic)
+			}
+		}
+	})
+
+	t.Run("should reject invalid encodings", func(t *testing.T) {
+		var card Card
+		if err := card.UnmarshalBinary([]byte{52}); err == nil {
+			t.Error("Expected error for value 52")
+		}
+		if err := card.UnmarshalBinary([]byte{1, 2}); err == nil {
+			t.Error("Expected error for 2-byte encoding")
+		}
+	})
+}
+
+// TestCard_JSON tests the SDK-compatible JSON shape
+func TestCard_JSON(t *testing.T) {
+	t.Run("should use lowercase SDK field names", func(t *testing.T) {
+		card, _ := CardFromValue(39)
+
+		data, err := json.Marshal(card)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+
+		expected := `{"suit":4,"rank":1,"value":39,"mnemonic":"AS"}`
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("should round trip every card", func(t *testing.T) {
+		for value := 0; value < 52; value++ {
+			card, _ := CardFromValue(value)
+			data, _ := json.Marshal(card)
+
+			var decoded Card
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if decoded != card {
+				t.Errorf("Expected %+v, got %+v", card, decoded)
+			}
+		}
+	})
+
+	t.Run("should derive the card from suit and rank", func(t *testing.T) {
+		var card Card
+		if err := json.Unmarshal([]byte(`{"suit":3,"rank":10}`), &card); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if card.Mnemonic != "TH" || card.Value != 35 {
+			t.Errorf("Expected TH with value 35, got %+v", card)
+		}
+	})
+
+	t.Run("should reject inconsistent fields", func(t *testing.T) {
+		inputs := []string{
+			`{"suit":1,"rank":1,"value":39,"mnemonic":"AS"}`,
+			`{"suit":4,"rank":1,"value":39,"mnemonic":"KS"}`,
+			`{"value":60}`,
+			`{"mnemonic":"AS"}`,
+		}
+		for _, input := range inputs {
+			var card Card
+			if err := json.Unmarshal([]byte(input), &card); err == nil {
+				t.Errorf("Expected error for %s", input)
+			}
+		}
+	})
+}
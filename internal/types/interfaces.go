@@ -46,6 +46,7 @@ type IPoker interface {
 	// Betting/Pot
 	GetBets(round TexasHoldemRound) map[string]*big.Int
 	GetPot() *big.Int
+	GetPots() []Pot
 }
 
 // IDealer defines what the dealer position manager needs
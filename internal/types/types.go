@@ -1,31 +1,41 @@
 package types
 
-import "math/big"
+import (
+	"math/big"
+	"time"
+)
 
 // PlayerActionType represents actions that players can take
 type PlayerActionType string
 
 const (
-	ActionFold     PlayerActionType = "FOLD"
-	ActionCheck    PlayerActionType = "CHECK"
-	ActionCall     PlayerActionType = "CALL"
-	ActionBet      PlayerActionType = "BET"
-	ActionRaise    PlayerActionType = "RAISE"
-	ActionAllIn    PlayerActionType = "ALL_IN"
-	ActionMuck     PlayerActionType = "MUCK"
-	ActionShow     PlayerActionType = "SHOW"
+	ActionFold       PlayerActionType = "FOLD"
+	ActionCheck      PlayerActionType = "CHECK"
+	ActionCall       PlayerActionType = "CALL"
+	ActionBet        PlayerActionType = "BET"
+	ActionRaise      PlayerActionType = "RAISE"
+	ActionAllIn      PlayerActionType = "ALL_IN"
+	ActionMuck       PlayerActionType = "MUCK"
+	ActionShow       PlayerActionType = "SHOW"
+	ActionSmallBlind PlayerActionType = "POST_SMALL_BLIND"
+	ActionBigBlind   PlayerActionType = "POST_BIG_BLIND"
+	ActionAnte       PlayerActionType = "POST_ANTE"
+	ActionStraddle   PlayerActionType = "POST_STRADDLE"
+	ActionRunIt      PlayerActionType = "RUN_IT" // Amount is how many boards the player agrees to run
+	ActionBringIn    PlayerActionType = "POST_BRING_IN"
+	ActionDraw       PlayerActionType = "DRAW" // Amount is a bit mask of the hole cards to discard, bit 0 for the first
 )
 
 // NonPlayerActionType represents system actions
 type NonPlayerActionType string
 
 const (
-	ActionDeal     NonPlayerActionType = "DEAL"
-	ActionNewHand  NonPlayerActionType = "NEW_HAND"
-	ActionJoin     NonPlayerActionType = "JOIN"
-	ActionLeave    NonPlayerActionType = "LEAVE"
-	ActionSitIn    NonPlayerActionType = "SIT_IN"
-	ActionSitOut   NonPlayerActionType = "SIT_OUT"
+	ActionDeal    NonPlayerActionType = "DEAL"
+	ActionNewHand NonPlayerActionType = "NEW_HAND"
+	ActionJoin    NonPlayerActionType = "JOIN"
+	ActionLeave   NonPlayerActionType = "LEAVE"
+	ActionSitIn   NonPlayerActionType = "SIT_IN"
+	ActionSitOut  NonPlayerActionType = "SIT_OUT"
 )
 
 // PlayerStatus represents the status of a player in the game
@@ -48,8 +58,31 @@ const (
 	RoundTurn     TexasHoldemRound = "TURN"
 	RoundRiver    TexasHoldemRound = "RIVER"
 	RoundShowdown TexasHoldemRound = "SHOWDOWN"
+	RoundEnd      TexasHoldemRound = "END" // Pot awarded, waiting for the next hand
+
+	// Seven card stud streets, each dealing one card to every player
+	RoundThirdStreet   TexasHoldemRound = "THIRD_STREET" // Two down and one up
+	RoundFourthStreet  TexasHoldemRound = "FOURTH_STREET"
+	RoundFifthStreet   TexasHoldemRound = "FIFTH_STREET"
+	RoundSixthStreet   TexasHoldemRound = "SIXTH_STREET"
+	RoundSeventhStreet TexasHoldemRound = "SEVENTH_STREET" // Down, or a shared up card if the deck runs short
+
+	// Draw game rounds. Betting rounds alternate with draws, where each
+	// player in turn discards and is dealt replacements.
+	RoundPreDraw        TexasHoldemRound = "PRE_DRAW" // Betting after the deal
+	RoundFirstDraw      TexasHoldemRound = "FIRST_DRAW"
+	RoundPostFirstDraw  TexasHoldemRound = "POST_FIRST_DRAW"
+	RoundSecondDraw     TexasHoldemRound = "SECOND_DRAW"
+	RoundPostSecondDraw TexasHoldemRound = "POST_SECOND_DRAW"
+	RoundThirdDraw      TexasHoldemRound = "THIRD_DRAW"
+	RoundPostThirdDraw  TexasHoldemRound = "POST_THIRD_DRAW"
 )
 
+// IsDraw reports whether the round is a draw rather than a betting round
+func (r TexasHoldemRound) IsDraw() bool {
+	return r == RoundFirstDraw || r == RoundSecondDraw || r == RoundThirdDraw
+}
+
 // GameFormat represents the format of the poker game
 type GameFormat string
 
@@ -63,8 +96,13 @@ const (
 type GameVariant string
 
 const (
-	VariantTexasHoldem GameVariant = "TEXAS_HOLDEM"
-	VariantOmaha       GameVariant = "OMAHA"
+	VariantTexasHoldem   GameVariant = "TEXAS_HOLDEM"
+	VariantOmaha         GameVariant = "OMAHA"
+	VariantOmahaHiLo     GameVariant = "OMAHA_HI_LO"     // Split pot, eight-or-better low
+	VariantShortDeck     GameVariant = "SHORT_DECK"      // 36-card deck, 6 through A
+	VariantSevenCardStud GameVariant = "SEVEN_CARD_STUD" // No board, played by the stud engine
+	VariantFiveCardDraw  GameVariant = "FIVE_CARD_DRAW"  // One draw, high hand wins, played by the draw engine
+	VariantTripleDraw    GameVariant = "TRIPLE_DRAW_2_7" // Three draws, 2-7 low wins, played by the draw engine
 )
 
 // Suit represents a card suit (matches TypeScript SDK SUIT enum)
@@ -114,6 +152,39 @@ type Winner struct {
 	Description string
 }
 
+// AnteType represents who posts the ante
+type AnteType string
+
+const (
+	AnteClassic  AnteType = "CLASSIC"   // Every player posts the ante
+	AnteBigBlind AnteType = "BIG_BLIND" // The big blind posts the ante for the table
+	AnteButton   AnteType = "BUTTON"    // The button posts the ante for the table
+)
+
+// StraddleType represents who may straddle pre-flop
+type StraddleType string
+
+const (
+	StraddleNone   StraddleType = ""
+	StraddleUTG    StraddleType = "UTG"    // The player left of the big blind may post two big blinds
+	StraddleButton StraddleType = "BUTTON" // The button may post two big blinds (Mississippi straddle)
+)
+
+// BettingStructure represents how much a player may bet or raise
+type BettingStructure string
+
+const (
+	BettingNoLimit    BettingStructure = "NO_LIMIT"    // Any amount up to the player's stack
+	BettingPotLimit   BettingStructure = "POT_LIMIT"   // Up to the size of the pot after calling
+	BettingFixedLimit BettingStructure = "FIXED_LIMIT" // Fixed bets and raises, capped per round
+)
+
+// Pot represents the main pot or a side pot and the players who can win it
+type Pot struct {
+	Amount   *big.Int `json:"amount"`
+	Eligible []string `json:"eligible"`
+}
+
 // GameOptions represents the configuration options for a poker game
 type GameOptions struct {
 	Format         GameFormat
@@ -123,5 +194,74 @@ type GameOptions struct {
 	MinPlayers     int
 	MaxPlayers     int
 	Ante           *big.Int
-	RakePercentage float64
+	AnteType       AnteType     // Who posts Ante, classic if unset
+	Straddle       StraddleType // Straddle offered in cash games, none if unset
+	RakePercentage float64      // Deprecated: use Rake, which avoids float math on chips
+	MinBuyIn       *big.Int
+	MaxBuyIn       *big.Int
+	Rake           *RakeOptions     // nil for no rake
+	Betting        BettingStructure // No-limit if unset
+	SmallBet       *big.Int         // Fixed-limit bet pre-flop and on the flop, the big blind if unset
+	BigBet         *big.Int         // Fixed-limit bet on the turn and river, twice the small bet if unset
+	RaiseCap       int              // Most fixed-limit bets and raises in a round, 4 if unset. Lifted when heads-up.
+	BringIn        *big.Int         // Stud forced bet from the lowest up-card, which may complete to the small bet
+	MaxRuns        int              // Most boards run when players are all-in before the river in cash games, once if unset
+}
+
+// HandOptions configures a single hand of a cash game. The zero value is a
+// standard hand.
+type HandOptions struct {
+	BombPot *big.Int // Ante posted by every player in place of the blinds, skipping pre-flop betting. nil for none.
+	Boards  int      // Boards dealt, each winning an equal share of every pot. One if unset.
+}
+
+// IsStandard reports whether the options leave the hand as normal
+func (o HandOptions) IsStandard() bool {
+	return (o.BombPot == nil || o.BombPot.Sign() == 0) && o.Boards <= 1
+}
+
+// RakeOptions configures the house rake taken from each pot
+type RakeOptions struct {
+	BasisPoints   int              // Rake in hundredths of a percent, 500 is 5%
+	Cap           *big.Int         // Most rake taken from one hand, nil for no cap
+	CapsByPlayers map[int]*big.Int // Caps for hands dealt to at least this many players, overriding Cap
+	NoFlopNoDrop  bool             // Take no rake from hands that end before the flop
+}
+
+// BlindLevel is one step of a tournament's blind structure. A level ends after
+// Hands hands or once Duration has passed, whichever comes first; with
+// neither set it lasts for the rest of the tournament.
+type BlindLevel struct {
+	SmallBlind *big.Int
+	BigBlind   *big.Int
+	Ante       *big.Int      // nil for no ante
+	Hands      int           // Hands played at the level, unlimited if unset
+	Duration   time.Duration // Time spent at the level, unlimited if unset
+}
+
+// SitAndGoOptions configures a Sit-and-Go tournament
+type SitAndGoOptions struct {
+	Game          GameOptions // Table options. The blinds and ante come from the first level.
+	StartingChips *big.Int
+	Levels        []BlindLevel
+	PrizePool     *big.Int
+	Payouts       []int            // Share of the prize pool in basis points by finishing place, first place first
+	Clock         func() time.Time // Time source for timed levels, time.Now if nil
+}
+
+// TournamentResult is a player's finishing place and prize
+type TournamentResult struct {
+	Address  string   `json:"address"`
+	Position int      `json:"position"` // 1 for the winner
+	Prize    *big.Int `json:"prize"`
+	Hand     int      `json:"hand"` // Hand in which the player busted, or the last hand for the winner
+}
+
+// RakeRecord is the rake taken from one hand
+type RakeRecord struct {
+	HandNumber int        `json:"handNumber"`
+	Players    int        `json:"players"`
+	Pots       []*big.Int `json:"pots"` // Rake from each pot, main pot first
+	Total      *big.Int   `json:"total"`
+	Timestamp  int64      `json:"timestamp"`
 }